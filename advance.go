@@ -0,0 +1,34 @@
+package progress
+
+import (
+	"slices"
+	"time"
+)
+
+// WithAutoAdvance makes Step.Done start the next step that is not started yet, so strictly linear pipelines
+// only need to mark each step as done. The terminated steps are passed over, and nothing is started if the
+// next remaining step is already in progress or has a function, left to Progress.Run, see Step.SetFunc.
+func WithAutoAdvance() Option {
+	return func(p *Progress) { p.autoAdvance = true }
+}
+
+// advance starts the next not started step after 'step' if auto-advance is enabled, see WithAutoAdvance.
+// The caller is responsible for holding the main lock.
+func (p *Progress) advance(step *Step, now time.Time) {
+	if !p.autoAdvance {
+		return
+	}
+	idx := slices.Index(p.Steps, step)
+	if idx < 0 {
+		return
+	}
+	for _, next := range p.Steps[idx+1:] {
+		if next.State.IsTerminal() {
+			continue
+		}
+		if next.State == StateNotStarted && next.fn == nil {
+			next.start(now)
+		}
+		return
+	}
+}
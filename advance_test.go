@@ -0,0 +1,35 @@
+package progress_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestWithAutoAdvance(t *testing.T) {
+	prog := progress.New(progress.WithAutoAdvance())
+	steps := prog.AddSteps("a", "b", "c", "d", "e")
+	steps[0].Start()
+	steps[0].Done()
+	require.Equal(t, progress.StateInProgress, steps[1].State)
+
+	// terminated steps are passed over
+	steps[2].Skip("")
+	steps[1].Done()
+	require.Equal(t, progress.StateInProgress, steps[3].State)
+
+	// steps having a function are left to Run
+	steps[4].SetFunc(func(context.Context, *progress.Step) error { return nil })
+	steps[3].Done()
+	require.Equal(t, progress.StateNotStarted, steps[4].State)
+	require.NoError(t, prog.Run(context.Background()))
+	require.Equal(t, progress.StateDone, prog.Snapshot().State)
+
+	// disabled by default
+	prog = progress.New()
+	steps = prog.AddSteps("a", "b")
+	steps[0].Done()
+	require.Equal(t, progress.StateNotStarted, steps[1].State)
+}
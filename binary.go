@@ -0,0 +1,69 @@
+package progress
+
+import (
+	"bytes"
+	"encoding/gob"
+	"time"
+)
+
+// binaryProgress is the gob representation of a Progress.
+type binaryProgress struct {
+	Version   int
+	Steps     []*Step
+	CreatedAt time.Time
+	Events    []Event
+	// Data holds the data of each step encoded with the DataCodec, when configured.
+	Data [][]byte
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using gob.
+// It is also used by encoding/gob when encoding a Progress.
+// Custom types stored in Step.Data need to be registered with gob.Register, unless a DataCodec is configured.
+func (p *Progress) MarshalBinary() ([]byte, error) {
+	p.mainMutex.RLock()
+	defer p.mainMutex.RUnlock()
+	encoded := binaryProgress{
+		Version:   SchemaVersion,
+		Steps:     p.Steps,
+		CreatedAt: p.CreatedAt,
+		Events:    p.events,
+	}
+	if p.dataCodec != nil {
+		// the data is encoded apart, on copies of the steps
+		encoded.Steps = make([]*Step, len(p.Steps))
+		encoded.Data = make([][]byte, len(p.Steps))
+		for idx, step := range p.Steps {
+			data, err := step.MarshalData()
+			if err != nil {
+				return nil, err
+			}
+			copied := *step
+			copied.Data = nil
+			encoded.Steps[idx] = &copied
+			encoded.Data[idx] = data
+		}
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(encoded); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for data produced by MarshalBinary.
+func (p *Progress) UnmarshalBinary(data []byte) error {
+	var decoded binaryProgress
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&decoded); err != nil {
+		return err
+	}
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	p.Steps = decoded.Steps
+	p.CreatedAt = decoded.CreatedAt
+	for idx, data := range decoded.Data {
+		if idx < len(p.Steps) && len(data) > 0 {
+			p.Steps[idx].rawData = data
+		}
+	}
+	return p.restore(decoded.Version, decoded.Events)
+}
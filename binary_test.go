@@ -0,0 +1,65 @@
+package progress_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+type binaryTestData struct {
+	Foo string
+	Bar int
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	gob.Register(binaryTestData{})
+
+	prog := progress.New()
+	prog.AddStep("step1").SetDescription("hello").SetData(42).Done()
+	prog.AddStep("step2").SetData(binaryTestData{Foo: "foo", Bar: 1337}).SetActor("worker-1").Start()
+	prog.AddStep("step3").SetProgress(0.3)
+	prog.AddStep("step4")
+
+	out, err := prog.MarshalBinary()
+	require.NoError(t, err)
+
+	var loaded progress.Progress
+	require.NoError(t, loaded.UnmarshalBinary(out))
+	require.True(t, prog.CreatedAt.Equal(loaded.CreatedAt))
+	require.Len(t, loaded.Steps, 4)
+	require.Equal(t, "hello", loaded.Get("step1").Description)
+	require.Equal(t, 42, loaded.Get("step1").Data)
+	require.Equal(t, binaryTestData{Foo: "foo", Bar: 1337}, loaded.Get("step2").Data)
+	require.Equal(t, "worker-1", loaded.Get("step2").Actor)
+	require.Equal(t, 0.3, loaded.Get("step3").Progress)
+	require.Equal(t, progress.StateNotStarted, loaded.Get("step4").State)
+	require.Equal(t, prog.Events()[3].StepID, loaded.Events()[3].StepID)
+	require.Equal(t, prog.Snapshot().Progress, loaded.Snapshot().Progress)
+
+	loaded.Get("step2").Done()
+	require.Equal(t, progress.StateInProgress, loaded.Snapshot().State)
+
+	require.Error(t, loaded.UnmarshalBinary([]byte("invalid")))
+}
+
+func TestBinary_gob(t *testing.T) {
+	type checkpoint struct {
+		Name     string
+		Progress *progress.Progress
+	}
+	prog := progress.New()
+	prog.AddStep("step1").Done()
+	prog.AddStep("step2")
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(checkpoint{Name: "test", Progress: prog}))
+
+	var decoded checkpoint
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+	require.Equal(t, "test", decoded.Name)
+	require.Len(t, decoded.Progress.Steps, 2)
+	require.Equal(t, progress.StateDone, decoded.Progress.Get("step1").State)
+}
@@ -0,0 +1,72 @@
+// Package boltstore provides a bbolt implementation of progress.Store for single-binary tools.
+package boltstore // import "moul.io/progress/boltstore"
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.etcd.io/bbolt"
+	"moul.io/progress"
+)
+
+const defaultBucket = "progress"
+
+// Store persists progresses as JSON documents in a bbolt bucket.
+type Store struct {
+	db     *bbolt.DB
+	bucket []byte
+}
+
+var _ progress.Store = (*Store)(nil)
+
+// New returns a Store using 'db'.
+// Progresses are stored in 'bucket', or in "progress" if empty; the bucket is created on the first Save.
+func New(db *bbolt.DB, bucket string) *Store {
+	if bucket == "" {
+		bucket = defaultBucket
+	}
+	return &Store{db: db, bucket: []byte(bucket)}
+}
+
+// Save implements progress.Store.
+func (s *Store) Save(ctx context.Context, id string, prog *progress.Progress) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(prog)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(s.bucket)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(id), data)
+	})
+}
+
+// Load implements progress.Store.
+func (s *Store) Load(ctx context.Context, id string) (*progress.Progress, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var prog *progress.Progress
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(s.bucket)
+		if bucket == nil {
+			return progress.ErrProgressNotFound
+		}
+		data := bucket.Get([]byte(id))
+		if data == nil {
+			return progress.ErrProgressNotFound
+		}
+		// data is only valid during the transaction, it is decoded before returning
+		prog = &progress.Progress{}
+		return json.Unmarshal(data, prog)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return prog, nil
+}
@@ -0,0 +1,72 @@
+package boltstore_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"moul.io/progress"
+	"moul.io/progress/boltstore"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.db")
+	db, err := bbolt.Open(path, 0o600, nil)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	store := boltstore.New(db, "")
+
+	_, err = store.Load(ctx, "migration")
+	require.Equal(t, progress.ErrProgressNotFound, err)
+
+	prog := progress.New()
+	prog.AddStep("step1").SetDescription("hello").Done()
+	prog.AddStep("step2").SetProgress(0.3)
+	prog.AddStep("step3")
+	require.NoError(t, store.Save(ctx, "migration", prog))
+
+	_, err = store.Load(ctx, "other")
+	require.Equal(t, progress.ErrProgressNotFound, err)
+
+	// the progress survives a restart
+	require.NoError(t, db.Close())
+	db, err = bbolt.Open(path, 0o600, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	store = boltstore.New(db, "")
+
+	loaded, err := store.Load(ctx, "migration")
+	require.NoError(t, err)
+	require.True(t, prog.CreatedAt.Equal(loaded.CreatedAt))
+	require.Len(t, loaded.Steps, 3)
+	require.Equal(t, "hello", loaded.Get("step1").Description)
+	require.Equal(t, progress.StateInProgress, loaded.Get("step2").State)
+	require.Equal(t, len(prog.Events()), len(loaded.Events()))
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.Equal(t, context.Canceled, store.Save(canceled, "migration", prog))
+}
+
+func TestStore_dataCodec(t *testing.T) {
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "progress.db"), 0o600, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	ctx := context.Background()
+	store := boltstore.New(db, "")
+	type user struct {
+		Name string `json:"name"`
+	}
+	registry := progress.NewTypeRegistry()
+	registry.Register("user", user{})
+	prog := progress.New(progress.WithDataCodec(registry))
+	prog.AddStep("step1").SetData(user{Name: "foo"}).Done()
+	require.NoError(t, store.Save(ctx, "job", prog))
+
+	loaded, err := progress.OpenStore(ctx, store, "job", progress.WithDataCodec(registry))
+	require.NoError(t, err)
+	require.Equal(t, user{Name: "foo"}, loaded.MustGet("step1").Data)
+}
@@ -0,0 +1,115 @@
+package progress
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrChildCycle is returned when a Progress would become its own descendant with AddChild.
+var ErrChildCycle = errors.New("progress: a progress cannot be its own child")
+
+// AddChild creates and returns a new Step with the provided 'id' tracking the 'child' Progress,
+// i.e., for an orchestrator composed of sub-tools each producing their own Progress.
+//
+// The step follows the snapshot of the child until it becomes terminal or is closed: it is started
+// with the child, its Progress is the completion rate of the child, its Units and TotalUnits are the
+// finished and total steps of the child, and it is done, failed, or canceled with the child.
+// The snapshot of the Progress contains the snapshots of its children, see Snapshot.Children.
+// A non-empty, unique 'id' and a 'child' not already an ancestor are required, else it will panic.
+func (p *Progress) AddChild(id string, child *Progress) *Step {
+	if child.isAncestorOf(p) {
+		panic(ErrChildCycle)
+	}
+	step := p.AddStep(id)
+	p.mainMutex.Lock()
+	step.child = child
+	p.mainMutex.Unlock()
+
+	subscriber := child.Subscribe()
+	if step.syncChild().IsTerminal() {
+		child.Unsubscribe(subscriber)
+		return step
+	}
+	go func() {
+		for range subscriber {
+			step.syncChild()
+		}
+		step.syncChild()
+	}()
+	return step
+}
+
+// Child returns the Progress tracked by the step if it was created with AddChild, else nil.
+func (s *Step) Child() *Progress {
+	s.parent.mainMutex.RLock()
+	defer s.parent.mainMutex.RUnlock()
+	return s.child
+}
+
+// isAncestorOf returns true if the Progress is 'other' or one of its ancestors through AddChild.
+func (p *Progress) isAncestorOf(other *Progress) bool {
+	if p == other {
+		return true
+	}
+	for _, child := range p.children() {
+		if child.isAncestorOf(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// children returns the children of the Progress by step ID.
+func (p *Progress) children() map[string]*Progress {
+	p.mainMutex.RLock()
+	defer p.mainMutex.RUnlock()
+	var ret map[string]*Progress
+	for _, step := range p.Steps {
+		if step.child != nil {
+			if ret == nil {
+				ret = make(map[string]*Progress)
+			}
+			ret[step.ID] = step.child
+		}
+	}
+	return ret
+}
+
+// syncChild updates the step from the snapshot of its child, and returns the state of the child.
+func (s *Step) syncChild() State {
+	snapshot := s.child.Snapshot()
+	p := s.parent
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	if s.State.IsTerminal() {
+		return snapshot.State
+	}
+
+	if snapshot.StartedAt != nil && s.StartedAt == nil {
+		startedAt := *snapshot.StartedAt
+		s.StartedAt = &startedAt
+	}
+	s.Units = int64(snapshot.Completed + snapshot.Skipped)
+	s.TotalUnits = int64(snapshot.Total)
+	s.Progress = snapshot.Progress
+	doneAt := p.now()
+	if snapshot.DoneAt != nil {
+		doneAt = *snapshot.DoneAt
+	}
+	switch snapshot.State {
+	case StateInProgress, StateStopped:
+		s.setState(StateInProgress, p.now())
+	case StateDone:
+		s.done(doneAt)
+	case StateFailed:
+		s.fail(fmt.Errorf("%d of %d steps failed", snapshot.Failed, snapshot.Total), doneAt)
+	case StateCanceled:
+		s.cancel(doneAt)
+	}
+	if !s.State.IsTerminal() {
+		p.publishStep(s)
+	} else if p.isTerminal() {
+		p.terminate()
+	}
+	return snapshot.State
+}
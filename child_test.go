@@ -0,0 +1,63 @@
+package progress_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestAddChild(t *testing.T) {
+	parent := progress.New()
+	parent.AddStep("prepare").Done()
+	child := progress.New()
+	child.AddSteps("a", "b", "c", "d")
+	step := parent.AddChild("upload", child)
+	require.Equal(t, child, step.Child())
+	require.Equal(t, progress.StateNotStarted, step.State)
+
+	child.MustGet("a").Done()
+	child.MustGet("b").Start()
+	require.Eventually(t, func() bool {
+		snapshot := parent.Snapshot()
+		return snapshot.State == progress.StateInProgress && snapshot.Progress == (1+0.375)/2
+	}, time.Second, time.Millisecond)
+	snapshot := parent.Snapshot()
+	require.Equal(t, 4, snapshot.Children["upload"].Total)
+	require.Equal(t, 1, snapshot.Children["upload"].Completed)
+	require.Equal(t, int64(1), parent.MustGet("upload").Units)
+	require.Equal(t, int64(4), parent.MustGet("upload").TotalUnits)
+
+	child.MustGet("b").Done()
+	child.MustGet("c").Done()
+	child.MustGet("d").Done()
+	select {
+	case <-parent.DoneCh():
+	case <-time.After(time.Second):
+		t.Fatal("the parent should be done")
+	}
+	require.Equal(t, progress.StateDone, parent.Snapshot().State)
+	require.Equal(t, child.Snapshot().DoneAt, parent.MustGet("upload").DoneAt)
+}
+
+func TestAddChild_failed(t *testing.T) {
+	child := progress.New()
+	child.AddStep("a").Fail(errors.New("oops"))
+	child.AddStep("b").Done()
+
+	// a terminal child is synchronized immediately
+	parent := progress.New()
+	step := parent.AddChild("child", child)
+	require.Equal(t, progress.StateFailed, step.State)
+	require.Equal(t, "1 of 2 steps failed", step.Error)
+}
+
+func TestAddChild_cycle(t *testing.T) {
+	prog := progress.New()
+	child := progress.New()
+	prog.AddChild("child", child)
+	require.PanicsWithValue(t, progress.ErrChildCycle, func() { child.AddChild("parent", prog) })
+	require.PanicsWithValue(t, progress.ErrChildCycle, func() { prog.AddChild("self", prog) })
+}
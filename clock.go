@@ -0,0 +1,52 @@
+package progress
+
+import "time"
+
+// Clock provides the current time used for the timestamps and durations of a Progress, see WithClock.
+type Clock interface {
+	Now() time.Time
+}
+
+// TickerClock is a Clock also providing the tickers of the periodic helpers of a Progress, i.e., Watchdog,
+// EmitEvery, AttachFile, and AttachStore, so a manual clock can drive them. The tickers of the time package are used with other clocks.
+type TickerClock interface {
+	Clock
+	// NewTicker returns a chan receiving the time every 'd', and a func stopping the ticker.
+	NewTicker(d time.Duration) (ch <-chan time.Time, stop func())
+}
+
+// Clock returns the clock of the Progress configured with WithClock, or the system clock by default,
+// so the integrations can use the same timeline as the Progress.
+func (p *Progress) Clock() Clock {
+	if p.clock == nil {
+		return systemClock{}
+	}
+	return p.clock
+}
+
+// systemClock is the default Clock, based on time.Now.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// now returns the current time of the clock of the Progress.
+func (p *Progress) now() time.Time {
+	if p == nil || p.clock == nil {
+		return time.Now()
+	}
+	return p.clock.Now()
+}
+
+// since returns the time elapsed since 't' according to the clock of the Progress.
+func (p *Progress) since(t time.Time) time.Duration {
+	return p.now().Sub(t)
+}
+
+// newTicker returns a ticker of the clock of the Progress, see TickerClock.
+func (p *Progress) newTicker(d time.Duration) (ch <-chan time.Time, stop func()) {
+	if clock, ok := p.clock.(TickerClock); ok {
+		return clock.NewTicker(d)
+	}
+	ticker := time.NewTicker(d)
+	return ticker.C, ticker.Stop
+}
@@ -0,0 +1,163 @@
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// DataCodec encodes and decodes Step.Data in the serialized representations of a Progress (JSON, YAML, and
+// binary) and in the stores, so typed payloads survive serialization.
+// The step ID is provided to allow choosing a type per step.
+type DataCodec interface {
+	MarshalData(stepID string, data interface{}) (json.RawMessage, error)
+	UnmarshalData(stepID string, raw json.RawMessage) (interface{}, error)
+}
+
+// WithDataCodec configures the codec used to encode and decode Step.Data, see SetDataCodec.
+// It is also accepted by Open and OpenStore to decode the restored data.
+func WithDataCodec(codec DataCodec) Option {
+	return func(p *Progress) { p.dataCodec = codec }
+}
+
+// SetDataCodec configures the codec used to encode and decode Step.Data.
+// When decoding, the codec needs to be configured before calling json.Unmarshal, yaml.Unmarshal, or
+// UnmarshalBinary.
+func (p *Progress) SetDataCodec(codec DataCodec) {
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	p.dataCodec = codec
+}
+
+// MarshalData returns the JSON representation of Step.Data, encoded with the DataCodec of the Progress if any,
+// for the stores persisting the data apart from the step. It returns nil if the step has no data.
+func (s *Step) MarshalData() (json.RawMessage, error) {
+	if s.Data == nil {
+		return nil, nil
+	}
+	data, err := s.marshalData()
+	if err != nil {
+		return nil, err
+	}
+	if raw, ok := data.(json.RawMessage); ok {
+		return raw, nil
+	}
+	return json.Marshal(data)
+}
+
+// marshalData returns the value used as Data in the serialized representation of the step.
+func (s *Step) marshalData() (interface{}, error) {
+	if s.Data == nil || s.parent == nil || s.parent.dataCodec == nil {
+		return s.Data, nil
+	}
+	raw, err := s.parent.dataCodec.MarshalData(s.ID, s.Data)
+	if err != nil {
+		return nil, fmt.Errorf("step %q: %w", s.ID, err)
+	}
+	return raw, nil
+}
+
+// unmarshalData decodes the raw data kept by the unmarshalers using the codec of the parent,
+// or generically without codec.
+// The caller is responsible for holding the main lock.
+func (s *Step) unmarshalData() error {
+	raw := s.rawData
+	s.rawData = nil
+	if len(raw) == 0 {
+		return nil
+	}
+	if s.parent.dataCodec == nil {
+		if s.Data != nil {
+			return nil
+		}
+		return json.Unmarshal(raw, &s.Data)
+	}
+	data, err := s.parent.dataCodec.UnmarshalData(s.ID, raw)
+	if err != nil {
+		return fmt.Errorf("step %q: %w", s.ID, err)
+	}
+	s.Data = data
+	return nil
+}
+
+// TypeRegistry is a DataCodec wrapping the data in an envelope naming its registered type,
+// i.e., `{"@type":"user","@value":{"name":"foo"}}`.
+// Values of unregistered types are encoded as plain JSON and decoded generically;
+// the "@type" key is reserved to the envelope.
+type TypeRegistry struct {
+	mutex  sync.RWMutex
+	byName map[string]reflect.Type
+	byType map[reflect.Type]string
+}
+
+var _ DataCodec = (*TypeRegistry)(nil)
+
+// NewTypeRegistry returns an empty TypeRegistry.
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{
+		byName: make(map[string]reflect.Type),
+		byType: make(map[reflect.Type]string),
+	}
+}
+
+// Register associates 'name' with the type of 'value'.
+// Decoded values have the same type as 'value', pointer or not.
+func (r *TypeRegistry) Register(name string, value interface{}) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	typ := reflect.TypeOf(value)
+	r.byName[name] = typ
+	r.byType[typ] = name
+}
+
+type typedEnvelope struct {
+	Type  string          `json:"@type"`
+	Value json.RawMessage `json:"@value"`
+}
+
+// MarshalData implements DataCodec.
+func (r *TypeRegistry) MarshalData(_ string, data interface{}) (json.RawMessage, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	r.mutex.RLock()
+	name, found := r.byType[reflect.TypeOf(data)]
+	r.mutex.RUnlock()
+	if !found {
+		return raw, nil
+	}
+	return json.Marshal(typedEnvelope{Type: name, Value: raw})
+}
+
+// UnmarshalData implements DataCodec.
+func (r *TypeRegistry) UnmarshalData(_ string, raw json.RawMessage) (interface{}, error) {
+	var envelope typedEnvelope
+	if err := json.Unmarshal(raw, &envelope); err == nil && envelope.Type != "" {
+		r.mutex.RLock()
+		typ, found := r.byName[envelope.Type]
+		r.mutex.RUnlock()
+		if !found {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownDataType, envelope.Type)
+		}
+		if typ.Kind() == reflect.Ptr {
+			ptr := reflect.New(typ.Elem())
+			if err := json.Unmarshal(envelope.Value, ptr.Interface()); err != nil {
+				return nil, err
+			}
+			return ptr.Interface(), nil
+		}
+		ptr := reflect.New(typ)
+		if err := json.Unmarshal(envelope.Value, ptr.Interface()); err != nil {
+			return nil, err
+		}
+		return ptr.Elem().Interface(), nil
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
@@ -0,0 +1,145 @@
+package progress_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"moul.io/progress"
+)
+
+type codecTestUser struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestTypeRegistry(t *testing.T) {
+	registry := progress.NewTypeRegistry()
+	registry.Register("user", codecTestUser{})
+	registry.Register("user-ptr", &codecTestUser{})
+
+	prog := progress.New()
+	prog.SetDataCodec(registry)
+	prog.AddStep("step1").SetData(codecTestUser{Name: "foo", Age: 42})
+	prog.AddStep("step2").SetData(&codecTestUser{Name: "bar"})
+	prog.AddStep("step3").SetData([]string{"hello", "world"})
+	prog.AddStep("step4")
+	prog.AddStep("step5").SetData(map[string]interface{}{"type": "user", "name": "baz"})
+
+	out, err := json.Marshal(prog)
+	require.NoError(t, err)
+	require.Contains(t, string(out), `"data":{"@type":"user","@value":{"name":"foo","age":42}}`)
+
+	loaded := &progress.Progress{}
+	loaded.SetDataCodec(registry)
+	require.NoError(t, json.Unmarshal(out, loaded))
+	require.Equal(t, codecTestUser{Name: "foo", Age: 42}, loaded.Get("step1").Data)
+	require.Equal(t, &codecTestUser{Name: "bar"}, loaded.Get("step2").Data)
+	require.Equal(t, []interface{}{"hello", "world"}, loaded.Get("step3").Data)
+	require.Nil(t, loaded.Get("step4").Data)
+	require.Equal(t, map[string]interface{}{"type": "user", "name": "baz"}, loaded.Get("step5").Data)
+
+	// without codec, the data is decoded generically
+	var generic progress.Progress
+	require.NoError(t, json.Unmarshal(out, &generic))
+	require.Equal(t, map[string]interface{}{
+		"@type":  "user",
+		"@value": map[string]interface{}{"name": "foo", "age": float64(42)},
+	}, generic.Get("step1").Data)
+
+	// unknown types
+	other := &progress.Progress{}
+	other.SetDataCodec(progress.NewTypeRegistry())
+	err = json.Unmarshal(out, other)
+	require.True(t, errors.Is(err, progress.ErrUnknownDataType))
+}
+
+// counterCodec stores the data of every step as a JSON string.
+type counterCodec struct{}
+
+func (counterCodec) MarshalData(_ string, data interface{}) (json.RawMessage, error) {
+	return json.Marshal(strconv.Itoa(data.(int)))
+}
+
+func (counterCodec) UnmarshalData(stepID string, raw json.RawMessage) (interface{}, error) {
+	var str string
+	if err := json.Unmarshal(raw, &str); err != nil {
+		return nil, err
+	}
+	return strconv.Atoi(str)
+}
+
+func TestDataCodec_custom(t *testing.T) {
+	prog := progress.New()
+	prog.SetDataCodec(counterCodec{})
+	prog.AddStep("step1").SetData(42)
+
+	out, err := json.Marshal(prog)
+	require.NoError(t, err)
+	require.Contains(t, string(out), `"data":"42"`)
+
+	loaded := &progress.Progress{}
+	loaded.SetDataCodec(counterCodec{})
+	require.NoError(t, json.Unmarshal(out, loaded))
+	require.Equal(t, 42, loaded.Get("step1").Data)
+}
+
+func TestDataCodec_formats(t *testing.T) {
+	registry := progress.NewTypeRegistry()
+	registry.Register("user", codecTestUser{})
+	prog := progress.New(progress.WithDataCodec(registry))
+	prog.AddStep("step1").SetData(codecTestUser{Name: "foo", Age: 42}).Done()
+	prog.AddStep("step2").SetData("hello")
+	prog.AddStep("step3")
+
+	check := func(t *testing.T, loaded *progress.Progress) {
+		t.Helper()
+		require.Equal(t, codecTestUser{Name: "foo", Age: 42}, loaded.MustGet("step1").Data)
+		require.Equal(t, "hello", loaded.MustGet("step2").Data)
+		require.Nil(t, loaded.MustGet("step3").Data)
+	}
+
+	t.Run("yaml", func(t *testing.T) {
+		out, err := yaml.Marshal(prog)
+		require.NoError(t, err)
+		require.Contains(t, string(out), "'@type': user")
+		loaded := progress.New(progress.WithDataCodec(registry))
+		require.NoError(t, yaml.Unmarshal(out, loaded))
+		check(t, loaded)
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		// the data is encoded by the codec, the type does not need to be registered with gob
+		out, err := prog.MarshalBinary()
+		require.NoError(t, err)
+		loaded := progress.New(progress.WithDataCodec(registry))
+		require.NoError(t, loaded.UnmarshalBinary(out))
+		check(t, loaded)
+
+		var generic progress.Progress
+		require.NoError(t, generic.UnmarshalBinary(out))
+		require.Equal(t, "user", generic.MustGet("step1").Data.(map[string]interface{})["@type"])
+	})
+
+	t.Run("open", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "progress.json")
+		require.NoError(t, prog.SaveFile(path))
+		loaded, err := progress.Open(path, progress.WithDataCodec(registry))
+		require.NoError(t, err)
+		check(t, loaded)
+	})
+
+	t.Run("store", func(t *testing.T) {
+		ctx := context.Background()
+		store := memoryStore{}
+		require.NoError(t, store.Save(ctx, "job", prog))
+		loaded, err := progress.OpenStore(ctx, store, "job", progress.WithDataCodec(registry))
+		require.NoError(t, err)
+		check(t, loaded)
+	})
+}
@@ -0,0 +1,64 @@
+package progress
+
+import (
+	"context"
+)
+
+type (
+	contextKey     struct{}
+	stepContextKey struct{}
+)
+
+// NewContext returns a copy of 'ctx' carrying the provided Progress.
+func NewContext(ctx context.Context, prog *Progress) context.Context {
+	return context.WithValue(ctx, contextKey{}, prog)
+}
+
+// FromContext retrieves the Progress stored in 'ctx' by NewContext.
+// If 'ctx' does not carry a Progress, nil is returned.
+func FromContext(ctx context.Context) *Progress {
+	prog, _ := ctx.Value(contextKey{}).(*Progress)
+	return prog
+}
+
+// NewStepContext returns a copy of 'ctx' carrying the provided Step and its Progress.
+// Progress.Run passes such a context to the step functions.
+func NewStepContext(ctx context.Context, step *Step) context.Context {
+	ctx = NewContext(ctx, step.parent)
+	return context.WithValue(ctx, stepContextKey{}, step)
+}
+
+// StepFromContext retrieves the Step stored in 'ctx' by NewStepContext, so functions called by a step function
+// can report the progress of the step.
+// If 'ctx' does not carry a Step, nil is returned.
+func StepFromContext(ctx context.Context) *Step {
+	step, _ := ctx.Value(stepContextKey{}).(*Step)
+	return step
+}
+
+// BindContext cancels every unfinished step of the Progress when 'ctx' is done, until the returned stop
+// function is called.
+// It is typically used with a context canceled on SIGINT to get an accurate final state.
+// Nothing is started if 'ctx' can never be done.
+func (p *Progress) BindContext(ctx context.Context) (stop func()) {
+	if ctx.Done() == nil {
+		return func() {}
+	}
+	unbind := context.AfterFunc(ctx, p.cancelRemaining)
+	return func() { unbind() }
+}
+
+func (p *Progress) cancelRemaining() {
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	if len(p.Steps) == 0 || p.isTerminal() {
+		return
+	}
+	now := p.now()
+	for _, step := range p.Steps {
+		if !step.State.IsTerminal() {
+			step.cancel(now)
+		}
+	}
+	p.terminate()
+}
@@ -0,0 +1,85 @@
+package progress_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	require.Nil(t, progress.FromContext(ctx))
+
+	prog := progress.New()
+	ctx = progress.NewContext(ctx, prog)
+	require.Equal(t, prog, progress.FromContext(ctx))
+
+	// the progress is still reachable from derived contexts
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	require.Equal(t, prog, progress.FromContext(ctx))
+}
+
+func TestBindContext(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Done()
+	prog.AddStep("step2").Start()
+	prog.AddStep("step3")
+	ch := prog.Subscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer prog.BindContext(ctx)()
+	cancel()
+
+	// the subscriber is closed once every step is canceled
+	for range ch {
+	}
+
+	require.Equal(t, progress.StateDone, prog.Get("step1").State)
+	require.Equal(t, progress.StateCanceled, prog.Get("step2").State)
+	require.Equal(t, progress.StateCanceled, prog.Get("step3").State)
+	require.NotNil(t, prog.Get("step2").DoneAt)
+	require.NotZero(t, prog.Get("step2").Duration())
+	require.Zero(t, prog.Get("step3").Duration())
+
+	snapshot := prog.Snapshot()
+	require.Equal(t, progress.StateCanceled, snapshot.State)
+	require.Equal(t, 1, snapshot.Completed)
+	require.Equal(t, 2, snapshot.Canceled)
+	require.Equal(t, 0, snapshot.InProgress)
+	require.Equal(t, 0, snapshot.NotStarted)
+}
+
+func TestBindContext_stop(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Start()
+	prog.BindContext(context.Background())() // noop
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := prog.BindContext(ctx)
+	stop()
+	stop()
+	cancel() // the steps are not canceled once unbound
+	require.Equal(t, progress.StateInProgress, prog.Get("step1").State)
+}
+
+func TestStep_Cancel(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Cancel()
+	require.Equal(t, progress.StateCanceled, prog.Get("step1").State)
+	require.Panics(t, func() { prog.Get("step1").Cancel() })
+	require.Equal(t, progress.StateCanceled, prog.Snapshot().State)
+}
+
+func TestStepContext(t *testing.T) {
+	ctx := context.Background()
+	require.Nil(t, progress.StepFromContext(ctx))
+
+	prog := progress.New()
+	step := prog.AddStep("step1")
+	ctx = progress.NewStepContext(ctx, step)
+	require.Equal(t, step, progress.StepFromContext(ctx))
+	require.Equal(t, prog, progress.FromContext(ctx))
+}
@@ -0,0 +1,46 @@
+package progress
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{"id", "description", "state", "started_at", "done_at", "duration", "error"}
+
+// WriteCSV writes one row per step to 'w', preceded by a header row.
+// Timestamps are formatted using RFC 3339 and durations are expressed in seconds.
+func (p *Progress) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	p.mainMutex.RLock()
+	rows := make([][]string, 0, len(p.Steps))
+	for _, step := range p.Steps {
+		rows = append(rows, []string{
+			step.ID,
+			step.Description,
+			string(step.State),
+			csvTime(step.StartedAt),
+			csvTime(step.DoneAt),
+			strconv.FormatFloat(step.Duration().Seconds(), 'f', -1, 64),
+			step.Error,
+		})
+	}
+	p.mainMutex.RUnlock()
+
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,39 @@
+package progress_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestWriteCSV(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").SetDescription("hello, world").Done()
+	prog.AddStep("step2").Start()
+	prog.AddStep("step3")
+
+	var buf bytes.Buffer
+	require.NoError(t, prog.WriteCSV(&buf))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	require.Equal(t, []string{"id", "description", "state", "started_at", "done_at", "duration", "error"}, records[0])
+
+	require.Equal(t, "step1", records[1][0])
+	require.Equal(t, "hello, world", records[1][1])
+	require.Equal(t, "done", records[1][2])
+	startedAt, err := time.Parse(time.RFC3339Nano, records[1][3])
+	require.NoError(t, err)
+	require.True(t, prog.Get("step1").StartedAt.Equal(startedAt))
+	require.NotEmpty(t, records[1][4])
+
+	require.Equal(t, "in progress", records[2][2])
+	require.Empty(t, records[2][4])
+
+	require.Equal(t, []string{"step3", "", "not started", "", "", "0", ""}, records[3])
+}
@@ -0,0 +1,37 @@
+package progress
+
+import "encoding/json"
+
+// DataAs returns the Data of 'step' as a T, and whether it could be converted.
+//
+// Data set with SetData is returned as is if it is a T. Data decoded from JSON without a DataCodec,
+// i.e., a map[string]interface{}, is converted to a T by encoding it to JSON and decoding it again.
+func DataAs[T any](step *Step) (T, bool) {
+	var ret T
+	step.parent.mainMutex.RLock()
+	data := step.Data
+	step.parent.mainMutex.RUnlock()
+	if data == nil {
+		return ret, false
+	}
+	if typed, ok := data.(T); ok {
+		return typed, true
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return ret, false
+	}
+	if err := json.Unmarshal(raw, &ret); err != nil {
+		return ret, false
+	}
+	return ret, true
+}
+
+// MustDataAs is equivalent to DataAs but panics if the Data of 'step' cannot be converted to a T.
+func MustDataAs[T any](step *Step) T {
+	ret, ok := DataAs[T](step)
+	if !ok {
+		panic("progress: cannot convert the data of step " + step.ID)
+	}
+	return ret
+}
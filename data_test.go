@@ -0,0 +1,38 @@
+package progress_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+type downloadData struct {
+	URL  string `json:"url"`
+	Size int    `json:"size"`
+}
+
+func TestDataAs(t *testing.T) {
+	prog := progress.New()
+	step := prog.AddStep("download").SetData(downloadData{URL: "https://example.com", Size: 42})
+	data, ok := progress.DataAs[downloadData](step)
+	require.True(t, ok)
+	require.Equal(t, 42, data.Size)
+	_, ok = progress.DataAs[string](step)
+	require.False(t, ok)
+
+	// decoded data is converted through JSON
+	raw, err := json.Marshal(prog)
+	require.NoError(t, err)
+	loaded := progress.New()
+	require.NoError(t, json.Unmarshal(raw, loaded))
+	require.IsType(t, map[string]interface{}{}, loaded.MustGet("download").Data)
+	require.Equal(t, data, progress.MustDataAs[downloadData](loaded.MustGet("download")))
+
+	_, ok = progress.DataAs[int](loaded.MustGet("download"))
+	require.False(t, ok)
+	_, ok = progress.DataAs[string](prog.AddStep("empty"))
+	require.False(t, ok)
+	require.Panics(t, func() { progress.MustDataAs[int](loaded.MustGet("download")) })
+}
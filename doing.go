@@ -0,0 +1,54 @@
+package progress
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DoingFormat configures how the Doing field of the snapshots is built from the in-progress steps,
+// see WithDoingFormat.
+type DoingFormat struct {
+	// UseIDs uses the step IDs instead of their descriptions.
+	UseIDs bool
+	// Separator joins the steps, ", " if empty.
+	Separator string
+	// Max is the maximum number of steps listed, the others being summarized as "+N more";
+	// values below 1 list every step.
+	Max int
+}
+
+// WithDoingFormat configures how the Doing field of the snapshots is built.
+// By default, it joins the descriptions of every in-progress step, or their IDs if empty, with ", ".
+func WithDoingFormat(format DoingFormat) Option {
+	return func(p *Progress) { p.doingFormat = format }
+}
+
+// format returns the Doing field for the in-progress 'steps'.
+func (f DoingFormat) format(steps []*Step) string {
+	separator := f.Separator
+	if separator == "" {
+		separator = ", "
+	}
+	listed := steps
+	if f.Max > 0 && len(steps) > f.Max {
+		listed = steps[:f.Max]
+	}
+	var builder strings.Builder
+	for idx, step := range listed {
+		if idx > 0 {
+			builder.WriteString(separator)
+		}
+		if f.UseIDs {
+			builder.WriteString(step.ID)
+		} else {
+			builder.WriteString(step.title())
+		}
+	}
+	if more := len(steps) - len(listed); more > 0 {
+		builder.WriteString(separator)
+		builder.WriteByte('+')
+		builder.WriteString(strconv.Itoa(more))
+		builder.WriteString(" more")
+	}
+	return builder.String()
+}
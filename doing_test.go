@@ -0,0 +1,30 @@
+package progress_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestWithDoingFormat(t *testing.T) {
+	start := func(prog *progress.Progress) {
+		for i := 1; i <= 5; i++ {
+			prog.AddStep(fmt.Sprintf("step%d", i)).SetDescription(fmt.Sprintf("step %d", i)).Start()
+		}
+	}
+
+	prog := progress.New()
+	start(prog)
+	require.Equal(t, "step 1, step 2, step 3, step 4, step 5", prog.Snapshot().Doing)
+
+	prog = progress.New(progress.WithDoingFormat(progress.DoingFormat{UseIDs: true, Separator: " | ", Max: 2}))
+	start(prog)
+	require.Equal(t, "step1 | step2 | +3 more", prog.Snapshot().Doing)
+	require.Equal(t, "step1 | step2 | +3 more", prog.Redacted(progress.RedactOmit, progress.RedactDescription).Snapshot().Doing)
+
+	prog = progress.New(progress.WithDoingFormat(progress.DoingFormat{Max: 5}))
+	start(prog)
+	require.Equal(t, "step 1, step 2, step 3, step 4, step 5", prog.Snapshot().Doing)
+}
@@ -0,0 +1,38 @@
+package progress
+
+// DoneCh returns a chan that is closed when every step of the Progress reaches a terminal state.
+// If steps are added after the chan was closed, subsequent calls return a new chan.
+func (p *Progress) DoneCh() <-chan struct{} {
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	if p.doneCh == nil {
+		p.doneCh = make(chan struct{})
+		if p.isTerminal() {
+			close(p.doneCh)
+			p.doneChClosed = true
+		}
+	}
+	return p.doneCh
+}
+
+// terminate notifies the subscribers and the DoneCh waiters that the Progress is terminal.
+// The caller is responsible for holding the main lock.
+func (p *Progress) terminate() {
+	p.closeSubscribers()
+	if p.doneCh != nil && !p.doneChClosed {
+		close(p.doneCh)
+		p.doneChClosed = true
+	}
+}
+
+// IsDone returns true if the chan returned by DoneCh is closed, without blocking.
+// The chan returned by Subscribe is never closed when subscribing to a done Progress, so the streaming
+// helpers check it right after subscribing.
+func (p *Progress) IsDone() bool {
+	select {
+	case <-p.DoneCh():
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,48 @@
+package progress_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestDoneCh(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1")
+	prog.AddStep("step2")
+	ch := prog.DoneCh()
+	require.Equal(t, ch, prog.DoneCh())
+
+	go func() {
+		prog.Get("step1").Start()
+		time.Sleep(10 * time.Millisecond)
+		prog.Get("step1").Done()
+		prog.Get("step2").Cancel()
+	}()
+
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatal("DoneCh was not closed")
+	}
+	require.True(t, prog.Snapshot().State.IsTerminal())
+	require.True(t, prog.IsDone())
+
+	// already terminal, the chan is still closed
+	<-prog.DoneCh()
+
+	// adding a step reopens the progress
+	prog.AddStep("step3")
+	ch2 := prog.DoneCh()
+	select {
+	case <-ch2:
+		t.Fatal("DoneCh should not be closed")
+	default:
+	}
+	require.False(t, prog.IsDone())
+	prog.Get("step3").Done()
+	<-ch2
+	require.True(t, prog.IsDone())
+}
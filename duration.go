@@ -0,0 +1,130 @@
+package progress
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurationFormat defines how durations are encoded in the JSON representations.
+type DurationFormat int
+
+const (
+	// DurationNanoseconds encodes durations as an integer number of nanoseconds (default).
+	DurationNanoseconds DurationFormat = iota
+	// DurationMilliseconds encodes durations as an integer number of milliseconds.
+	DurationMilliseconds
+	// DurationSeconds encodes durations as a floating number of seconds.
+	DurationSeconds
+	// DurationISO8601 encodes durations as ISO 8601 strings, i.e., "PT1M12.5S".
+	DurationISO8601
+	// DurationHuman encodes durations as humanized strings rounded to the millisecond, i.e., "1m12.5s".
+	DurationHuman
+)
+
+// SetDurationFormat configures how durations are encoded in the JSON representations of the Progress,
+// its steps, and its snapshots.
+func (p *Progress) SetDurationFormat(format DurationFormat) {
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	p.durationFormat = format
+}
+
+// WithHumanDurations adds a humanized string next to each duration in the JSON representations of the Progress,
+// its steps, and its snapshots, i.e., "duration_human": "1m12s", whatever the configured DurationFormat.
+func WithHumanDurations() Option {
+	return func(p *Progress) { p.humanDurations = true }
+}
+
+// MarshalJSON is a custom JSON marshaler encoding the durations using the format configured on the Progress.
+func (s Snapshot) MarshalJSON() ([]byte, error) {
+	type alias Snapshot
+	type enriched struct {
+		alias
+		TotalDuration           interface{} `json:"total_duration,omitempty"`
+		TotalDurationHuman      string      `json:"total_duration_human,omitempty"`
+		StepDuration            interface{} `json:"step_duration,omitempty"`
+		StepDurationHuman       string      `json:"step_duration_human,omitempty"`
+		CompletionEstimate      interface{} `json:"completion_estimate,omitempty"`
+		CompletionEstimateHuman string      `json:"completion_estimate_human,omitempty"`
+	}
+	ret := enriched{
+		alias:              alias(s),
+		TotalDuration:      s.durationFormat.encode(s.TotalDuration),
+		StepDuration:       s.durationFormat.encode(s.StepDuration),
+		CompletionEstimate: s.durationFormat.encode(s.CompletionEstimate),
+	}
+	if s.humanDurations {
+		ret.TotalDurationHuman = humanDuration(s.TotalDuration)
+		ret.StepDurationHuman = humanDuration(s.StepDuration)
+		ret.CompletionEstimateHuman = humanDuration(s.CompletionEstimate)
+	}
+	return json.Marshal(&ret)
+}
+
+func (s *Step) durationFormat() DurationFormat {
+	if s.parent == nil {
+		return DurationNanoseconds
+	}
+	return s.parent.durationFormat
+}
+
+// humanDuration returns the humanized 'd' if enabled on the Progress, see WithHumanDurations.
+func (s *Step) humanDuration(d time.Duration) string {
+	if s.parent == nil || !s.parent.humanDurations {
+		return ""
+	}
+	return humanDuration(d)
+}
+
+// humanDuration returns 'd' rounded to the millisecond as a string, i.e., "1m12.5s", or "" for zero durations.
+func humanDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+// encode returns the JSON value of a duration, or nil for zero durations so they can be omitted.
+func (f DurationFormat) encode(d time.Duration) interface{} {
+	if d == 0 {
+		return nil
+	}
+	switch f {
+	case DurationMilliseconds:
+		return d.Milliseconds()
+	case DurationSeconds:
+		return d.Seconds()
+	case DurationISO8601:
+		return formatISO8601(d)
+	case DurationHuman:
+		return humanDuration(d)
+	default:
+		return int64(d)
+	}
+}
+
+func formatISO8601(d time.Duration) string {
+	var builder strings.Builder
+	if d < 0 {
+		builder.WriteByte('-')
+		d = -d
+	}
+	builder.WriteString("PT")
+	if hours := d / time.Hour; hours > 0 {
+		builder.WriteString(strconv.FormatInt(int64(hours), 10))
+		builder.WriteByte('H')
+		d -= hours * time.Hour
+	}
+	if minutes := d / time.Minute; minutes > 0 {
+		builder.WriteString(strconv.FormatInt(int64(minutes), 10))
+		builder.WriteByte('M')
+		d -= minutes * time.Minute
+	}
+	if d > 0 || builder.Len() <= len("-PT") {
+		builder.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64))
+		builder.WriteByte('S')
+	}
+	return builder.String()
+}
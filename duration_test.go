@@ -0,0 +1,106 @@
+package progress_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progresstest"
+)
+
+func TestSetDurationFormat(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Start()
+	step := prog.Get("step1")
+	started := time.Now().Add(-72500 * time.Millisecond)
+	step.StartedAt = &started
+	prog.AddStep("step2").Done()
+	done := started.Add(72500 * time.Millisecond)
+	prog.Get("step2").StartedAt = &started
+	prog.Get("step2").DoneAt = &done
+
+	decode := func(t *testing.T) (interface{}, interface{}) {
+		t.Helper()
+		out, err := json.Marshal(prog)
+		require.NoError(t, err)
+		var decoded struct {
+			Steps []struct {
+				Duration interface{} `json:"duration"`
+			} `json:"steps"`
+			Snapshot struct {
+				TotalDuration interface{} `json:"total_duration"`
+			} `json:"snapshot"`
+		}
+		require.NoError(t, json.Unmarshal(out, &decoded))
+		require.NotNil(t, decoded.Snapshot.TotalDuration)
+		return decoded.Steps[1].Duration, decoded.Steps[0].Duration
+	}
+
+	// default
+	done2, _ := decode(t)
+	require.Equal(t, float64(72500*time.Millisecond), done2)
+
+	prog.SetDurationFormat(progress.DurationMilliseconds)
+	done2, _ = decode(t)
+	require.Equal(t, float64(72500), done2)
+
+	prog.SetDurationFormat(progress.DurationSeconds)
+	done2, _ = decode(t)
+	require.Equal(t, 72.5, done2)
+
+	prog.SetDurationFormat(progress.DurationISO8601)
+	done2, inProgress := decode(t)
+	require.Equal(t, "PT1M12.5S", done2)
+	require.Regexp(t, `^PT1M12\.\d+S$`, inProgress)
+
+	prog.SetDurationFormat(progress.DurationHuman)
+	done2, _ = decode(t)
+	require.Equal(t, "1m12.5s", done2)
+
+	// standalone snapshots use the format of their progress
+	out, err := json.Marshal(prog.Snapshot())
+	require.NoError(t, err)
+	require.Regexp(t, `"total_duration":"1m12\.\d+s"`, string(out))
+
+	// the JSON can still be loaded
+	out, err = json.Marshal(prog)
+	require.NoError(t, err)
+	var loaded progress.Progress
+	require.NoError(t, json.Unmarshal(out, &loaded))
+	require.Len(t, loaded.Steps, 2)
+}
+
+func TestWithHumanDurations(t *testing.T) {
+	clock := progresstest.NewClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	prog := progress.New(progress.WithClock(clock), progress.WithHumanDurations())
+	prog.AddStep("step1").Start()
+	clock.Advance(72 * time.Second)
+	prog.Get("step1").Done()
+	prog.AddStep("step2")
+
+	out, err := json.Marshal(prog)
+	require.NoError(t, err)
+	var decoded struct {
+		Steps []struct {
+			Duration      int64  `json:"duration"`
+			DurationHuman string `json:"duration_human"`
+		} `json:"steps"`
+		Snapshot struct {
+			TotalDuration      int64  `json:"total_duration"`
+			TotalDurationHuman string `json:"total_duration_human"`
+		} `json:"snapshot"`
+	}
+	require.NoError(t, json.Unmarshal(out, &decoded))
+	require.Equal(t, int64(72*time.Second), decoded.Steps[0].Duration)
+	require.Equal(t, "1m12s", decoded.Steps[0].DurationHuman)
+	require.Empty(t, decoded.Steps[1].DurationHuman)
+	require.Equal(t, int64(72*time.Second), decoded.Snapshot.TotalDuration)
+	require.Equal(t, "1m12s", decoded.Snapshot.TotalDurationHuman)
+
+	// disabled by default
+	out, err = json.Marshal(progress.New())
+	require.NoError(t, err)
+	require.NotContains(t, string(out), "_human")
+}
@@ -0,0 +1,43 @@
+package progress
+
+import (
+	"context"
+	"time"
+)
+
+// EmitEvery calls 'fn' with a fresh snapshot immediately, then every 'interval' if the Progress has changed.
+// It blocks until the Progress becomes terminal, in which case a final snapshot is emitted and nil is returned,
+// or until 'ctx' is done, in which case the context error is returned.
+// If the Progress is already terminal, the final snapshot is the only one emitted.
+// The interval is measured with the tickers of the clock of the Progress, see TickerClock.
+func (p *Progress) EmitEvery(ctx context.Context, interval time.Duration, fn func(Snapshot)) error {
+	ticks, stopTicker := p.newTicker(interval)
+	defer stopTicker()
+
+	lastRevision := p.currentRevision()
+	if !p.IsDone() {
+		fn(p.Snapshot())
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.DoneCh():
+			fn(p.Snapshot())
+			return nil
+		case <-ticks:
+			revision := p.currentRevision()
+			if revision == lastRevision {
+				continue
+			}
+			lastRevision = revision
+			fn(p.Snapshot())
+		}
+	}
+}
+
+func (p *Progress) currentRevision() uint64 {
+	p.mainMutex.RLock()
+	defer p.mainMutex.RUnlock()
+	return p.revision
+}
@@ -0,0 +1,70 @@
+package progress_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progresstest"
+)
+
+func TestEmitEvery(t *testing.T) {
+	clock := progresstest.NewClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	prog := progress.New(progress.WithClock(clock))
+	prog.AddStep("step1")
+	prog.AddStep("step2")
+
+	snapshots := make(chan progress.Snapshot, 100)
+	errCh := make(chan error)
+	go func() {
+		errCh <- prog.EmitEvery(context.Background(), 5*time.Second, func(snapshot progress.Snapshot) {
+			snapshots <- snapshot
+		})
+	}()
+
+	// initial emission
+	require.Equal(t, progress.StateNotStarted, (<-snapshots).State)
+
+	// nothing changed, nothing is emitted; each tick is received once the previous one is processed
+	clock.Advance(5 * time.Second)
+	clock.Advance(5 * time.Second)
+	require.Empty(t, snapshots)
+
+	prog.Get("step1").Start()
+	clock.Advance(5 * time.Second)
+	require.Equal(t, progress.StateInProgress, (<-snapshots).State)
+
+	prog.Get("step1").Done()
+	prog.Get("step2").Done()
+	require.NoError(t, <-errCh)
+	var last progress.Snapshot
+	for len(snapshots) > 0 {
+		last = <-snapshots
+	}
+	require.Equal(t, progress.StateDone, last.State)
+}
+
+func TestEmitEvery_terminal(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Done()
+	var snapshots []progress.Snapshot
+	err := prog.EmitEvery(context.Background(), time.Hour, func(snapshot progress.Snapshot) {
+		snapshots = append(snapshots, snapshot)
+	})
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	require.Equal(t, progress.StateDone, snapshots[0].State)
+}
+
+func TestEmitEvery_cancel(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	err := prog.EmitEvery(ctx, time.Hour, func(progress.Snapshot) { calls++ })
+	require.Equal(t, context.Canceled, err)
+	require.Equal(t, 1, calls)
+}
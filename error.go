@@ -0,0 +1,41 @@
+package progress
+
+import "errors"
+
+// SetError records 'err' on the step without changing its state, i.e., for a non-fatal error,
+// see Fail to also mark the step as failed. The message of 'err' is stored in Step.Error, so it is
+// serialized with the step. A nil 'err' clears the error.
+// It returns itself (*Step) for chaining.
+func (s *Step) SetError(err error) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	s.setError(err)
+	s.parent.publishStep(s)
+	return s
+}
+
+// Err returns the error recorded with SetError or Fail, or nil.
+// The original error is returned, so it can be inspected with errors.Is and errors.As, unless the step
+// was restored from a serialized Progress: only its message is preserved then.
+func (s *Step) Err() error {
+	s.parent.mainMutex.RLock()
+	defer s.parent.mainMutex.RUnlock()
+	switch {
+	case s.Error == "":
+		return nil
+	case s.err != nil && s.err.Error() == s.Error:
+		return s.err
+	default:
+		return errors.New(s.Error)
+	}
+}
+
+// setError records 'err' on the step.
+// The caller is responsible for holding the main lock.
+func (s *Step) setError(err error) {
+	s.err = err
+	s.Error = ""
+	if err != nil {
+		s.Error = err.Error()
+	}
+}
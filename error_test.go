@@ -0,0 +1,40 @@
+package progress_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestStepError(t *testing.T) {
+	prog := progress.New()
+	step := prog.AddStep("step1").Start()
+	require.NoError(t, step.Err())
+
+	// a non-fatal error does not change the state
+	err := fmt.Errorf("cannot read cache: %w", fs.ErrNotExist)
+	step.SetError(err)
+	require.Equal(t, progress.StateInProgress, step.State)
+	require.Equal(t, "cannot read cache: file does not exist", step.Error)
+	require.ErrorIs(t, step.Err(), fs.ErrNotExist)
+	step.SetError(nil)
+	require.NoError(t, step.Err())
+	require.Empty(t, step.Error)
+
+	step.Fail(err)
+	require.Equal(t, err, step.Err())
+
+	// only the message survives a round-trip
+	raw, err := json.Marshal(prog)
+	require.NoError(t, err)
+	loaded := progress.New()
+	require.NoError(t, json.Unmarshal(raw, loaded))
+	loadedErr := loaded.MustGet("step1").Err()
+	require.EqualError(t, loadedErr, "cannot read cache: file does not exist")
+	require.False(t, errors.Is(loadedErr, fs.ErrNotExist))
+}
@@ -0,0 +1,161 @@
+package progress
+
+import "time"
+
+// Event represents a step state transition recorded in the Progress event log.
+type Event struct {
+	StepID string    `json:"step_id,omitempty" yaml:"step_id,omitempty"`
+	From   State     `json:"from,omitempty" yaml:"from,omitempty"`
+	To     State     `json:"to,omitempty" yaml:"to,omitempty"`
+	At     time.Time `json:"at,omitempty" yaml:"at,omitempty"`
+	Actor  string    `json:"actor,omitempty" yaml:"actor,omitempty"`
+}
+
+// Events returns a copy of the recorded state transitions, ordered from the oldest to the newest.
+func (p *Progress) Events() []Event {
+	p.mainMutex.RLock()
+	defer p.mainMutex.RUnlock()
+	if len(p.events) == 0 {
+		return nil
+	}
+	ret := make([]Event, len(p.events))
+	copy(ret, p.events)
+	return ret
+}
+
+// EventsSince returns a copy of the events recorded after 'last', or of every event if 'last' is nil or
+// is no longer in the event log, allowing a poller to only handle the new transitions.
+func (p *Progress) EventsSince(last *Event) []Event {
+	p.mainMutex.RLock()
+	defer p.mainMutex.RUnlock()
+	events := p.events
+	if last != nil {
+		for idx := len(events) - 1; idx >= 0; idx-- {
+			if event := events[idx]; event.StepID == last.StepID && event.To == last.To && event.At.Equal(last.At) {
+				events = events[idx+1:]
+				break
+			}
+		}
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	return append([]Event(nil), events...)
+}
+
+// SetEventLogLimit bounds the event log to the 'limit' most recent events.
+// A limit of 0 (the default) keeps every event.
+func (p *Progress) SetEventLogLimit(limit int) {
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	p.eventLogLimit = limit
+	p.trimEvents()
+}
+
+// recordEvent appends a transition to the event log.
+// The caller is responsible for holding the main lock.
+func (p *Progress) recordEvent(step *Step, from, to State, at time.Time) {
+	event := Event{
+		StepID: step.ID,
+		From:   from,
+		To:     to,
+		At:     at,
+		Actor:  step.Actor,
+	}
+	p.events = append(p.events, event)
+	p.lastTransition = at
+	p.trimEvents()
+	if len(p.eventSubs) > 0 {
+		// sent to the event subscribers by the next publishStep, once the step is fully updated
+		p.pendingEvents = append(p.pendingEvents, event)
+	}
+}
+
+func (p *Progress) trimEvents() {
+	if p.eventLogLimit <= 0 || len(p.events) <= p.eventLogLimit {
+		return
+	}
+	p.events = append(p.events[:0:0], p.events[len(p.events)-p.eventLogLimit:]...)
+}
+
+// setState updates the step state and records the transition if the state changed.
+// The caller is responsible for holding the main lock.
+func (s *Step) setState(state State, at time.Time) {
+	if s.State == state {
+		return
+	}
+	from := s.State
+	s.State = state
+	s.parent.recordEvent(s, from, state, at)
+	switch {
+	case state.IsTerminal() && s.doneCh != nil && !s.doneChClosed:
+		close(s.doneCh)
+		s.doneChClosed = true
+	case !state.IsTerminal() && s.doneChClosed:
+		s.doneCh = nil
+		s.doneChClosed = false
+	}
+}
+
+// StepEvent is a step state transition received from SubscribeEvents.
+type StepEvent struct {
+	Event
+	// Step is a copy of the step right after the transition.
+	Step *Step
+}
+
+// SubscribeEvents returns a chan receiving each step transition recorded from now on, in order, with a copy
+// of the step in its new state, i.e., to log or forward the transitions.
+// Like with Subscribe, the chan is closed when the Progress becomes terminal or is closed, see UnsubscribeEvents.
+func (p *Progress) SubscribeEvents() chan StepEvent {
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	subscriber := make(chan StepEvent, defaultSubscriberChanLength)
+	if p.eventSubs == nil {
+		p.eventSubs = make(map[chan StepEvent]struct{})
+	}
+	p.eventSubs[subscriber] = struct{}{}
+	return subscriber
+}
+
+// UnsubscribeEvents unregisters and closes a chan returned by SubscribeEvents.
+// Calling it on an already closed subscriber is a noop.
+func (p *Progress) UnsubscribeEvents(subscriber chan StepEvent) {
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	if _, found := p.eventSubs[subscriber]; !found {
+		return
+	}
+	close(subscriber)
+	delete(p.eventSubs, subscriber)
+}
+
+// publishEvents sends the transitions recorded since the last call to the event subscribers.
+// The transitions of 'step' are sent with a copy of it, the other ones with a copy of their current step.
+// The caller is responsible for holding the main lock.
+func (p *Progress) publishEvents(step *Step) {
+	events := p.pendingEvents
+	p.pendingEvents = nil
+	for _, event := range events {
+		current := step
+		if current == nil || current.ID != event.StepID {
+			current = nil
+			for _, other := range p.Steps {
+				if other.ID == event.StepID {
+					current = other
+					break
+				}
+			}
+		}
+		stepEvent := StepEvent{Event: event}
+		if current != nil {
+			stepEvent.Step = current.publishedCopy()
+		}
+		for subscriber := range p.eventSubs {
+			select {
+			case subscriber <- stepEvent:
+			case <-time.After(publishTimeout):
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package progress_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progresstest"
+)
+
+func TestEvents(t *testing.T) {
+	prog := progress.New()
+	require.Empty(t, prog.Events())
+
+	prog.AddStep("step1")
+	prog.AddStep("step2").SetActor("worker-1")
+	prog.Get("step1").Start()
+	prog.Get("step2").SetProgress(0.3)
+	prog.Get("step2").SetProgress(0.6) // no transition
+	prog.Get("step1").Done()
+	prog.Get("step2").Done()
+
+	events := prog.Events()
+	require.Len(t, events, 6)
+	expected := []struct {
+		id       string
+		from, to progress.State
+		actor    string
+	}{
+		{"step1", "", progress.StateNotStarted, ""},
+		{"step2", "", progress.StateNotStarted, ""},
+		{"step1", progress.StateNotStarted, progress.StateInProgress, ""},
+		{"step2", progress.StateNotStarted, progress.StateInProgress, "worker-1"},
+		{"step1", progress.StateInProgress, progress.StateDone, ""},
+		{"step2", progress.StateInProgress, progress.StateDone, "worker-1"},
+	}
+	for idx, exp := range expected {
+		require.Equal(t, exp.id, events[idx].StepID)
+		require.Equal(t, exp.from, events[idx].From)
+		require.Equal(t, exp.to, events[idx].To)
+		require.Equal(t, exp.actor, events[idx].Actor)
+		require.False(t, events[idx].At.IsZero())
+		if idx > 0 {
+			require.False(t, events[idx].At.Before(events[idx-1].At))
+		}
+	}
+
+	// the event log is part of the JSON output
+	out, err := json.Marshal(prog)
+	require.NoError(t, err)
+	var decoded struct {
+		Events []progress.Event `json:"events"`
+	}
+	require.NoError(t, json.Unmarshal(out, &decoded))
+	require.Len(t, decoded.Events, len(events))
+	for idx := range events {
+		require.Equal(t, events[idx].StepID, decoded.Events[idx].StepID)
+		require.Equal(t, events[idx].To, decoded.Events[idx].To)
+		require.True(t, events[idx].At.Equal(decoded.Events[idx].At))
+	}
+}
+
+func TestEvents_limit(t *testing.T) {
+	prog := progress.New()
+	prog.SetEventLogLimit(2)
+	prog.AddStep("step1")
+	prog.AddStep("step2")
+	prog.AddStep("step3")
+	events := prog.Events()
+	require.Len(t, events, 2)
+	require.Equal(t, "step2", events[0].StepID)
+	require.Equal(t, "step3", events[1].StepID)
+
+	require.Equal(t, events[1:], prog.EventsSince(&events[0]))
+	require.Empty(t, prog.EventsSince(&events[1]))
+
+	prog.SetEventLogLimit(1)
+	require.Len(t, prog.Events(), 1)
+	require.Equal(t, "step3", prog.Events()[0].StepID)
+	// the last seen event was trimmed from the log
+	require.Equal(t, prog.Events(), prog.EventsSince(&events[0]))
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	clock := progresstest.NewClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	prog := progress.New(progress.WithClock(clock))
+	prog.AddStep("a")
+	subscriber := prog.SubscribeEvents()
+
+	prog.MustGet("a").Start()
+	prog.MustGet("a").SetProgress(0.5) // no transition
+	clock.Advance(time.Minute)
+	prog.AddStep("b").SetDescription("second")
+	prog.MustGet("a").Fail(errors.New("boom"))
+	prog.MustGet("b").Skip("")
+
+	var events []progress.StepEvent
+	for event := range subscriber { // closed once the progress is terminal
+		events = append(events, event)
+	}
+	require.Len(t, events, 4)
+	require.Equal(t, progress.Event{StepID: "a", From: progress.StateNotStarted, To: progress.StateInProgress, At: clock.Now().Add(-time.Minute)}, events[0].Event)
+	require.Equal(t, progress.StateInProgress, events[0].Step.State)
+	require.Equal(t, progress.Event{StepID: "b", To: progress.StateNotStarted, At: clock.Now()}, events[1].Event)
+	require.Equal(t, progress.StateFailed, events[2].To)
+	require.Equal(t, "boom", events[2].Step.Error)
+	require.Equal(t, clock.Now(), *events[2].Step.DoneAt)
+	require.Equal(t, "second", events[3].Step.Description)
+	require.Equal(t, prog.Events()[1:], []progress.Event{events[0].Event, events[1].Event, events[2].Event, events[3].Event})
+
+	subscriber = prog.SubscribeEvents()
+	prog.UnsubscribeEvents(subscriber)
+	prog.UnsubscribeEvents(subscriber)
+	_, ok := <-subscriber
+	require.False(t, ok)
+}
@@ -86,7 +86,7 @@ func Example() {
 	//}
 }
 
-func ExampleProgressSubscribe() {
+func ExampleProgress_Subscribe() {
 	prog := progress.New()
 	defer prog.Close()
 	done := make(chan bool)
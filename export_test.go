@@ -0,0 +1,15 @@
+package progress
+
+// SetMigration registers 'migration' to upgrade the documents of 'version' until the returned function is
+// called, allowing the tests to exercise the migrations of every format while no real migration is needed.
+func SetMigration(version int, migration func(p *Progress) error) (reset func()) {
+	previous, found := migrations[version]
+	migrations[version] = migration
+	return func() {
+		if found {
+			migrations[version] = previous
+		} else {
+			delete(migrations, version)
+		}
+	}
+}
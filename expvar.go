@@ -0,0 +1,12 @@
+package progress
+
+import "expvar"
+
+// PublishExpvar registers the live Snapshot of the Progress as an expvar variable named 'name',
+// making it available on /debug/vars.
+// As with expvar.Publish, it panics if the name is already registered.
+func (p *Progress) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return p.Snapshot()
+	}))
+}
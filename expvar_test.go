@@ -0,0 +1,25 @@
+package progress_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestPublishExpvar(t *testing.T) {
+	prog := progress.New()
+	prog.PublishExpvar("progress_test_expvar")
+	prog.AddStep("step1").Start()
+
+	v := expvar.Get("progress_test_expvar")
+	require.NotNil(t, v)
+	var snapshot progress.Snapshot
+	require.NoError(t, json.Unmarshal([]byte(v.String()), &snapshot))
+	require.Equal(t, progress.StateInProgress, snapshot.State)
+	require.Equal(t, 1, snapshot.Total)
+
+	require.Panics(t, func() { prog.PublishExpvar("progress_test_expvar") })
+}
@@ -0,0 +1,119 @@
+package progress
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SaveFile atomically writes the JSON representation of the Progress to 'path'.
+// The content is written to a temporary file first, then renamed, so readers never see a partial file.
+// The permissions of an existing file are kept, else the file is created with the 0644 permissions.
+func (p *Progress) SaveFile(path string) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // noop if the rename succeeded
+
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// AttachFile keeps the file at 'path' up to date with the Progress using SaveFile.
+// If 'interval' is 0, the file is written on every change, else at most once per interval if something changed.
+// It returns a func that stops the auto-saving, writes the file a last time, and returns the first error encountered.
+func (p *Progress) AttachFile(path string, interval time.Duration) (stop func() error) {
+	return p.attach(func() error { return p.SaveFile(path) }, interval)
+}
+
+// AttachStore keeps the version of the Progress saved in 'store' with 'id' up to date, like AttachFile.
+func (p *Progress) AttachStore(ctx context.Context, store Store, id string, interval time.Duration) (stop func() error) {
+	return p.attach(func() error { return store.Save(ctx, id, p) }, interval)
+}
+
+// attach calls 'fn' on changes, see AttachFile.
+func (p *Progress) attach(fn func() error, interval time.Duration) (stop func() error) {
+	var (
+		stopCh   = make(chan struct{})
+		doneCh   = make(chan struct{})
+		once     sync.Once
+		firstErr error
+	)
+	save := func() {
+		if err := fn(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	go func() {
+		defer close(doneCh)
+		var tick <-chan time.Time
+		if interval > 0 {
+			ticks, stopTicker := p.newTicker(interval)
+			defer stopTicker()
+			tick = ticks
+		}
+
+		sub := p.Subscribe()
+		save()
+		dirty := false
+		for {
+			select {
+			case <-stopCh:
+				p.Unsubscribe(sub)
+				save()
+				return
+			case _, ok := <-sub:
+				if !ok {
+					// the progress became terminal, subscribe again to catch the steps added later
+					sub = p.Subscribe()
+				}
+				if interval == 0 {
+					save()
+				} else {
+					dirty = true
+				}
+			case <-tick:
+				if dirty {
+					save()
+					dirty = false
+				}
+			}
+		}
+	}()
+
+	return func() error {
+		once.Do(func() {
+			close(stopCh)
+			<-doneCh
+		})
+		return firstErr
+	}
+}
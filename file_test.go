@@ -0,0 +1,124 @@
+package progress_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progresstest"
+)
+
+func loadTestFile(t *testing.T, path string) *progress.Progress {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var prog progress.Progress
+	require.NoError(t, json.Unmarshal(data, &prog))
+	return &prog
+}
+
+func TestSaveFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "progress.json")
+
+	prog := progress.New()
+	prog.AddStep("step1").Done()
+	prog.AddStep("step2")
+	require.NoError(t, prog.SaveFile(path))
+
+	loaded := loadTestFile(t, path)
+	require.Len(t, loaded.Steps, 2)
+	require.Equal(t, progress.StateDone, loaded.Get("step1").State)
+
+	// no temporary file is left behind
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	require.Error(t, prog.SaveFile(filepath.Join(dir, "missing", "progress.json")))
+}
+
+func TestSaveFile_mode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix permissions")
+	}
+	path := filepath.Join(t.TempDir(), "progress.json")
+	prog := progress.New()
+	prog.AddStep("step1")
+
+	require.NoError(t, prog.SaveFile(path))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+
+	// the permissions of an existing file are kept
+	require.NoError(t, os.Chmod(path, 0o640))
+	require.NoError(t, prog.SaveFile(path))
+	info, err = os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+}
+
+func TestAttachFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+	prog := progress.New()
+	stop := prog.AttachFile(path, 0)
+
+	prog.AddStep("step1")
+	prog.AddStep("step2")
+	prog.Get("step1").Start()
+	require.Eventually(t, func() bool {
+		loaded := loadTestFile(t, path)
+		return len(loaded.Steps) == 2 && loaded.Get("step1").State == progress.StateInProgress
+	}, time.Second, 5*time.Millisecond)
+
+	prog.Get("step1").Done()
+	prog.Get("step2").Done()
+	prog.AddStep("step3")
+	require.NoError(t, stop())
+	require.NoError(t, stop())
+	require.Len(t, loadTestFile(t, path).Steps, 3)
+}
+
+func TestAttachFile_interval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+	prog := progress.New()
+	stop := prog.AttachFile(path, 10*time.Millisecond)
+	defer stop()
+
+	prog.AddStep("step1").Start()
+	require.Eventually(t, func() bool {
+		loaded := loadTestFile(t, path)
+		return len(loaded.Steps) == 1 && loaded.Get("step1").State == progress.StateInProgress
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestAttachFile_clock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+	clock := progresstest.NewClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	prog := progress.New(progress.WithClock(clock))
+	stop := prog.AttachFile(path, time.Hour)
+	defer stop()
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, time.Second, 5*time.Millisecond)
+
+	// the changes are saved on the ticks of the clock of the Progress
+	prog.AddStep("step1").Start()
+	require.Eventually(t, func() bool {
+		clock.Advance(time.Hour)
+		return len(loadTestFile(t, path).Steps) == 1
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestAttachFile_error(t *testing.T) {
+	prog := progress.New()
+	stop := prog.AttachFile(filepath.Join(t.TempDir(), "missing", "progress.json"), 0)
+	require.Error(t, stop())
+}
@@ -0,0 +1,96 @@
+package progress
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// RunGroup runs the functions of 'funcs' concurrently, each one tracked by the step matching its key,
+// like an errgroup.Group.
+//
+// Missing steps are created in the order of the keys. Each step is started before calling its function,
+// then marked as done if the function returns nil, or as failed with the returned error;
+// a function returning an error once the context is canceled marks its step as canceled.
+// The context passed to the functions is canceled as soon as one of them fails, and RunGroup returns
+// the first error once every function has returned.
+func RunGroup(ctx context.Context, prog *Progress, funcs map[string]func(ctx context.Context) error) error {
+	ids := make([]string, 0, len(funcs))
+	for id := range funcs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	steps := make([]*Step, len(ids))
+	for idx, id := range ids {
+		step := prog.Get(id)
+		if step == nil {
+			step = prog.AddStep(id)
+		}
+		steps[idx] = step
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for idx, id := range ids {
+		fn, step := funcs[id], steps[idx]
+		step.Start()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(ctx); err != nil {
+				if ctx.Err() != nil {
+					step.Cancel()
+				} else {
+					step.Fail(err)
+				}
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			step.Done()
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// GroupSummary contains the stats of the steps of a group, i.e., to render "Phase: build — 80%",
+// see Snapshot.Groups.
+type GroupSummary struct {
+	Group    string   `json:"group" yaml:"group"`
+	Snapshot Snapshot `json:"snapshot" yaml:"snapshot"`
+}
+
+// groupSummaries computes the stats of the steps of each group, in order of appearance,
+// or returns nil if no step has a group.
+// The caller is responsible for holding the main lock.
+func (p *Progress) groupSummaries() []GroupSummary {
+	var (
+		groups []string
+		steps  = make(map[string][]*Step)
+	)
+	for _, step := range p.Steps {
+		if step.Group == "" {
+			continue
+		}
+		if _, found := steps[step.Group]; !found {
+			groups = append(groups, step.Group)
+		}
+		steps[step.Group] = append(steps[step.Group], step)
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+	ret := make([]GroupSummary, 0, len(groups))
+	for _, group := range groups {
+		ret = append(ret, GroupSummary{Group: group, Snapshot: p.snapshot(steps[group], len(steps[group]))})
+	}
+	return ret
+}
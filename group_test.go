@@ -0,0 +1,63 @@
+package progress_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestRunGroup(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("b").SetDescription("existing")
+	err := progress.RunGroup(context.Background(), prog, map[string]func(context.Context) error{
+		"a": func(context.Context) error { return nil },
+		"b": func(context.Context) error { return nil },
+		"c": func(context.Context) error { return nil },
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "a", "c"}, []string{prog.Steps[0].ID, prog.Steps[1].ID, prog.Steps[2].ID})
+	require.Equal(t, "existing", prog.Get("b").Description)
+	require.Equal(t, progress.StateDone, prog.Snapshot().State)
+}
+
+func TestRunGroup_error(t *testing.T) {
+	prog := progress.New()
+	errFailed := errors.New("failed")
+	err := progress.RunGroup(context.Background(), prog, map[string]func(context.Context) error{
+		"fail": func(context.Context) error { return errFailed },
+		"wait": func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+	require.Equal(t, errFailed, err)
+	require.Equal(t, progress.StateFailed, prog.Get("fail").State)
+	require.Equal(t, "failed", prog.Get("fail").Error)
+	require.Equal(t, progress.StateCanceled, prog.Get("wait").State)
+	require.Equal(t, progress.StateFailed, prog.Snapshot().State)
+}
+
+func TestSnapshot_Groups(t *testing.T) {
+	require.Nil(t, progress.New().Snapshot().Groups)
+
+	prog := progress.New()
+	prog.AddStep("compile").SetGroup("build").Done()
+	prog.AddStep("fetch").SetGroup("deps").Done()
+	prog.AddStep("link").SetGroup("build").Start().SetProgress(0.6)
+	prog.AddStep("notes")
+	prog.AddStep("upload").SetGroup("publish")
+
+	groups := prog.Snapshot().Groups
+	require.Len(t, groups, 3)
+	require.Equal(t, "build", groups[0].Group)
+	require.Equal(t, progress.StateInProgress, groups[0].Snapshot.State)
+	require.Equal(t, 2, groups[0].Snapshot.Total)
+	require.Equal(t, 80.0, groups[0].Snapshot.Percent())
+	require.Equal(t, "deps", groups[1].Group)
+	require.Equal(t, progress.StateDone, groups[1].Snapshot.State)
+	require.Equal(t, "publish", groups[2].Group)
+	require.Equal(t, progress.StateNotStarted, groups[2].Snapshot.State)
+}
@@ -0,0 +1,98 @@
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Handler returns an http.Handler exposing the Progress as JSON.
+//
+//	GET /snapshot  returns the current Snapshot.
+//	GET /steps     returns the list of steps.
+//
+// Responses carry an ETag changing with the Progress, so clients can poll using If-None-Match.
+// Use http.StripPrefix to mount the handler on a sub-path.
+func Handler(prog *Progress) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		serveJSON(w, r, prog, func() interface{} { return prog.Snapshot() })
+	})
+	mux.HandleFunc("/steps", func(w http.ResponseWriter, r *http.Request) {
+		serveJSON(w, r, prog, func() interface{} {
+			steps := prog.clone().Steps
+			if steps == nil {
+				steps = []*Step{}
+			}
+			return steps
+		})
+	})
+	return mux
+}
+
+func serveJSON(w http.ResponseWriter, r *http.Request, prog *Progress, get func() interface{}) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	etag := prog.etag()
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	body, err := json.Marshal(get())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, _ = w.Write(body)
+}
+
+// etag returns a weak entity tag changing each time the Progress is updated.
+func (p *Progress) etag() string {
+	return `W/"` + p.revisionTag() + `"`
+}
+
+// revisionTag returns an identifier changing each time the Progress is updated.
+func (p *Progress) revisionTag() string {
+	p.mainMutex.RLock()
+	defer p.mainMutex.RUnlock()
+	return fmt.Sprintf("%x-%x", p.CreatedAt.UnixNano(), p.revision)
+}
+
+// ReadinessHandler returns an http.Handler suitable for readiness probes.
+// It responds with the current Snapshot as JSON, and a status code depending on the Progress state:
+// 200 when done, 500 when canceled or failed, and 503 otherwise.
+func ReadinessHandler(prog *Progress) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		snapshot := prog.Snapshot()
+		status := http.StatusServiceUnavailable
+		switch snapshot.State {
+		case StateDone:
+			status = http.StatusOK
+		case StateCanceled, StateFailed:
+			status = http.StatusInternalServerError
+		}
+
+		body, err := json.Marshal(snapshot)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if r.Method != http.MethodHead {
+			_, _ = w.Write(body)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package progress_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestHandler(t *testing.T) {
+	prog := progress.New()
+	handler := progress.Handler(prog)
+
+	get := func(path string, headers ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// empty progress
+	{
+		rec := get("/steps")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "[]", rec.Body.String())
+	}
+
+	prog.AddStep("step1").Done()
+	prog.AddStep("step2").Start()
+
+	// snapshot
+	rec := get("/snapshot")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+	var snapshot progress.Snapshot
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &snapshot))
+	require.Equal(t, progress.StateInProgress, snapshot.State)
+	require.Equal(t, 2, snapshot.Total)
+
+	// conditional request
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	rec = get("/snapshot", "If-None-Match", etag)
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	require.Empty(t, rec.Body.String())
+	prog.Get("step2").Done()
+	rec = get("/snapshot", "If-None-Match", etag)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotEqual(t, etag, rec.Header().Get("ETag"))
+
+	// steps
+	rec = get("/steps")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var steps []progress.Step
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &steps))
+	require.Len(t, steps, 2)
+	require.Equal(t, "step1", steps[0].ID)
+	require.Equal(t, progress.StateDone, steps[1].State)
+
+	// errors
+	require.Equal(t, http.StatusNotFound, get("/unknown").Code)
+	req := httptest.NewRequest(http.MethodPost, "/snapshot", nil)
+	postRec := httptest.NewRecorder()
+	handler.ServeHTTP(postRec, req)
+	require.Equal(t, http.StatusMethodNotAllowed, postRec.Code)
+
+	// mounted on a sub-path
+	mux := http.NewServeMux()
+	mux.Handle("/progress/", http.StripPrefix("/progress", handler))
+	subRec := httptest.NewRecorder()
+	mux.ServeHTTP(subRec, httptest.NewRequest(http.MethodGet, "/progress/snapshot", nil))
+	require.Equal(t, http.StatusOK, subRec.Code)
+}
+
+func TestReadinessHandler(t *testing.T) {
+	prog := progress.New()
+	handler := progress.ReadinessHandler(prog)
+	probe := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+		return rec
+	}
+
+	require.Equal(t, http.StatusServiceUnavailable, probe().Code)
+	prog.AddStep("step1").Start()
+	prog.AddStep("step2")
+	rec := probe()
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Contains(t, rec.Body.String(), `"state":"in progress"`)
+
+	prog.Get("step1").Done()
+	prog.Get("step2").Done()
+	rec = probe()
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"state":"done"`)
+
+	failed := progress.New()
+	failed.AddStep("step1").Cancel()
+	rec = httptest.NewRecorder()
+	progress.ReadinessHandler(failed).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,11 @@
+package progress
+
+// SetIndeterminate enables or disables the indeterminate mode, for phases where the completion rate is unknown,
+// i.e., while the steps are discovered.
+// Until the Progress is terminal, its snapshots are then flagged as Indeterminate, without completion rate
+// nor estimate, so renderers can draw a throbber instead of a misleading bar.
+func (p *Progress) SetIndeterminate(enabled bool) {
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	p.indeterminate = enabled
+}
@@ -0,0 +1,42 @@
+package progress_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestSetIndeterminate(t *testing.T) {
+	prog := progress.New()
+	prog.SetIndeterminate(true)
+	require.True(t, prog.Snapshot().Indeterminate)
+	prog.AddStep("a").Done()
+	prog.AddStep("b").Start().SetProgress(0.5)
+
+	snapshot := prog.Snapshot()
+	require.True(t, snapshot.Indeterminate)
+	require.Zero(t, snapshot.Progress)
+	require.Zero(t, snapshot.CompletionEstimate)
+	require.Zero(t, prog.Progress())
+	require.Contains(t, snapshot.String(), `1/2 — doing "b"`)
+	text, err := prog.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "1/2 doing=b failed=0 canceled=0", string(text))
+	out, err := json.Marshal(snapshot)
+	require.NoError(t, err)
+	require.Contains(t, string(out), `"indeterminate":true`)
+	require.NotContains(t, string(out), `"progress"`)
+
+	prog.SetIndeterminate(false)
+	require.Equal(t, 0.75, prog.Snapshot().Progress)
+
+	// terminal progresses are complete
+	prog.SetIndeterminate(true)
+	prog.MustGet("b").Done()
+	snapshot = prog.Snapshot()
+	require.False(t, snapshot.Indeterminate)
+	require.Equal(t, 1.0, snapshot.Progress)
+	require.Equal(t, 1.0, prog.Progress())
+}
@@ -0,0 +1,68 @@
+package progress
+
+import (
+	"io"
+	"os"
+)
+
+// Writer returns an io.Writer writing to 'w' and adding the written bytes to the units of 'step'.
+// Combined with Step.SetTotalUnits, it reports the live progress of a copy, i.e., with io.Copy.
+func Writer(step *Step, w io.Writer) io.Writer {
+	return &writer{step: step, w: w}
+}
+
+type writer struct {
+	step *Step
+	w    io.Writer
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	if n > 0 {
+		w.step.AddUnits(int64(n))
+	}
+	return n, err
+}
+
+// Reader returns an io.Reader reading from 'r' and adding the read bytes to the units of 'step'.
+// If the size of 'r' is known, i.e., it is a regular file or has a Len method like bytes.Reader,
+// the remaining size is used as the total units of the step; otherwise the total can be set
+// with Step.SetTotalUnits, i.e., from the Content-Length of an HTTP response.
+func Reader(step *Step, r io.Reader) io.Reader {
+	if size, ok := readerSize(r); ok {
+		step.SetTotalUnits(size)
+	}
+	return &reader{step: step, r: r}
+}
+
+type reader struct {
+	step *Step
+	r    io.Reader
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.step.AddUnits(int64(n))
+	}
+	return n, err
+}
+
+// readerSize returns the remaining size of 'r' if it can be known without reading it.
+func readerSize(r io.Reader) (int64, bool) {
+	switch typed := r.(type) {
+	case interface{ Len() int }:
+		return int64(typed.Len()), true
+	case *os.File:
+		info, err := typed.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0, false
+		}
+		offset, err := typed.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false
+		}
+		return info.Size() - offset, true
+	}
+	return 0, false
+}
@@ -0,0 +1,61 @@
+package progress_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestWriter(t *testing.T) {
+	prog := progress.New()
+	step := prog.AddStep("upload").SetTotalUnits(1000)
+
+	var buf bytes.Buffer
+	n, err := io.Copy(progress.Writer(step, &buf), strings.NewReader(strings.Repeat("a", 400)))
+	require.NoError(t, err)
+	require.Equal(t, int64(400), n)
+	require.Equal(t, 400, buf.Len())
+	require.Equal(t, int64(400), step.Units)
+	require.Equal(t, 0.4, step.Progress)
+	require.Equal(t, progress.StateInProgress, step.State)
+}
+
+func TestReader(t *testing.T) {
+	prog := progress.New()
+
+	// known size
+	step := prog.AddStep("read")
+	content, err := io.ReadAll(progress.Reader(step, strings.NewReader(strings.Repeat("a", 300))))
+	require.NoError(t, err)
+	require.Len(t, content, 300)
+	require.Equal(t, int64(300), step.TotalUnits)
+	require.Equal(t, int64(300), step.Units)
+	require.Equal(t, 1.0, step.Progress)
+
+	// file
+	path := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Repeat("b", 100)), 0o600))
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	step = prog.AddStep("file")
+	_, err = io.CopyN(io.Discard, progress.Reader(step, f), 25)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), step.TotalUnits)
+	require.Equal(t, 0.25, step.Progress)
+
+	// unknown size
+	step = prog.AddStep("download")
+	r := progress.Reader(step, io.LimitReader(strings.NewReader("hello world"), 5))
+	_, err = io.ReadAll(r)
+	require.NoError(t, err)
+	require.Zero(t, step.TotalUnits)
+	require.Equal(t, int64(5), step.Units)
+	require.Equal(t, progress.StateInProgress, step.State)
+}
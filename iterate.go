@@ -0,0 +1,75 @@
+package progress
+
+import (
+	"iter"
+	"maps"
+)
+
+// AllSteps returns an iterator over a consistent copy of the steps, taken when the iteration starts.
+// Unlike ranging over Progress.Steps, it is safe while the Progress is updated concurrently;
+// the yielded steps are detached copies, updating them does not update the Progress.
+func (p *Progress) AllSteps() iter.Seq[*Step] {
+	return func(yield func(*Step) bool) {
+		for _, step := range p.copySteps() {
+			if !yield(step) {
+				return
+			}
+		}
+	}
+}
+
+// ForEach calls 'fn' with a copy of each step, see AllSteps.
+func (p *Progress) ForEach(fn func(step *Step)) {
+	for _, step := range p.copySteps() {
+		fn(step)
+	}
+}
+
+// Filter returns a copy of the steps matching 'fn', in order, see AllSteps.
+func (p *Progress) Filter(fn func(step *Step) bool) []*Step {
+	var ret []*Step
+	for _, step := range p.copySteps() {
+		if fn(step) {
+			ret = append(ret, step)
+		}
+	}
+	return ret
+}
+
+// StepsByState returns a copy of the steps in one of the provided 'states', in order, see AllSteps.
+func (p *Progress) StepsByState(states ...State) []*Step {
+	return p.Filter(FilterStates(states...))
+}
+
+// copySteps returns a detached copy of the steps.
+func (p *Progress) copySteps() []*Step {
+	p.mainMutex.RLock()
+	defer p.mainMutex.RUnlock()
+	ret := make([]*Step, len(p.Steps))
+	for idx, step := range p.Steps {
+		ret[idx] = step.clone()
+	}
+	return ret
+}
+
+// clone returns a copy of the step not sharing its slices.
+// The caller is responsible for holding the main lock.
+func (s *Step) clone() *Step {
+	ret := *s
+	ret.Attempts = append([]Attempt(nil), s.Attempts...)
+	ret.Tags = append([]string(nil), s.Tags...)
+	ret.Logs = append([]LogLine(nil), s.Logs...)
+	ret.Metadata = maps.Clone(s.Metadata)
+	ret.dependencies = append([]string(nil), s.dependencies...)
+	return &ret
+}
+
+// publishedCopy returns a copy of the step for the subscribers, cheaper than clone.
+// Logf appends to the log in place, beyond the lines visible in the copy, and the capacity of the copied log
+// is clipped so appending to it never writes into the log of the step.
+// The caller is responsible for holding the main lock.
+func (s *Step) publishedCopy() *Step {
+	ret := *s
+	ret.Logs = ret.Logs[:len(ret.Logs):len(ret.Logs)]
+	return &ret
+}
@@ -0,0 +1,69 @@
+package progress_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestAllSteps(t *testing.T) {
+	prog := progress.New()
+	prog.AddSteps("a", "b", "c")
+	prog.MustGet("a").Done()
+
+	var ids []string
+	for step := range prog.AllSteps() {
+		ids = append(ids, step.ID)
+		if step.ID == "b" {
+			break
+		}
+	}
+	require.Equal(t, []string{"a", "b"}, ids)
+
+	// the yielded steps are copies
+	for step := range prog.AllSteps() {
+		step.Description = "updated"
+	}
+	require.Empty(t, prog.MustGet("a").Description)
+
+	var states []progress.State
+	prog.ForEach(func(step *progress.Step) { states = append(states, step.State) })
+	require.Equal(t, []progress.State{progress.StateDone, progress.StateNotStarted, progress.StateNotStarted}, states)
+}
+
+func TestAllSteps_concurrent(t *testing.T) {
+	prog := progress.New()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			prog.AddStep(fmt.Sprintf("step%d", i)).Start()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		for step := range prog.AllSteps() {
+			require.NotEmpty(t, step.ID)
+		}
+	}
+	wg.Wait()
+	count := 0
+	prog.ForEach(func(*progress.Step) { count++ })
+	require.Equal(t, 100, count)
+}
+
+func TestFilter(t *testing.T) {
+	prog := progress.New()
+	prog.AddSteps("a", "b", "c", "d")
+	prog.MustGet("a").Done()
+	prog.MustGet("b").Fail(fmt.Errorf("oops"))
+	prog.MustGet("c").Start()
+
+	require.Equal(t, []string{"b"}, stepIDs(prog.StepsByState(progress.StateFailed)))
+	require.Equal(t, []string{"c", "d"}, stepIDs(prog.StepsByState(progress.StateNotStarted, progress.StateInProgress)))
+	require.Empty(t, prog.StepsByState(progress.StateCanceled))
+	require.Equal(t, []string{"a", "c"}, stepIDs(prog.Filter(func(step *progress.Step) bool { return step.StartedAt != nil })))
+}
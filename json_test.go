@@ -0,0 +1,50 @@
+package progress_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").SetDescription("hello").Done()
+	prog.AddStep("step2").SetData("world").SetActor("worker-1").Start()
+	prog.AddStep("step3")
+
+	out, err := json.Marshal(prog)
+	require.NoError(t, err)
+
+	var loaded progress.Progress
+	require.NoError(t, json.Unmarshal(out, &loaded))
+	require.True(t, prog.CreatedAt.Equal(loaded.CreatedAt))
+	require.Len(t, loaded.Steps, 3)
+	require.Equal(t, "hello", loaded.Get("step1").Description)
+	require.Equal(t, progress.StateDone, loaded.Get("step1").State)
+	require.Equal(t, "world", loaded.Get("step2").Data)
+	require.Equal(t, "worker-1", loaded.Get("step2").Actor)
+	require.Equal(t, progress.StateInProgress, loaded.Get("step2").State)
+	require.True(t, prog.Get("step2").StartedAt.Equal(*loaded.Get("step2").StartedAt))
+	require.Equal(t, len(prog.Events()), len(loaded.Events()))
+
+	snapshot := loaded.Snapshot()
+	require.Equal(t, progress.StateInProgress, snapshot.State)
+	require.Equal(t, 1, snapshot.Completed)
+	require.Equal(t, 1, snapshot.InProgress)
+	require.Equal(t, 1, snapshot.NotStarted)
+
+	// the loaded progress can be resumed with the same API
+	loaded.Get("step2").Done()
+	loaded.Get("step3").Done()
+	require.Equal(t, progress.StateDone, loaded.Snapshot().State)
+	require.Equal(t, len(prog.Events())+2, len(loaded.Events()))
+}
+
+func TestJSONUnmarshal_invalid(t *testing.T) {
+	var prog progress.Progress
+	require.Equal(t, progress.ErrStepRequiresID, json.Unmarshal([]byte(`{"steps":[{"state":"done"}]}`), &prog))
+	require.Equal(t, progress.ErrStepIDShouldBeUnique, json.Unmarshal([]byte(`{"steps":[{"id":"a"},{"id":"a"}]}`), &prog))
+	require.Error(t, json.Unmarshal([]byte(`{"steps":42}`), &prog))
+}
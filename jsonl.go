@@ -0,0 +1,29 @@
+package progress
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// StreamEvents writes each step transition to 'w' as a JSON-encoded Event followed by a newline (JSON Lines),
+// until the Progress becomes terminal or the returned stop function is called.
+// The stop function returns the first write error.
+func (p *Progress) StreamEvents(w io.Writer) (stop func() error) {
+	subscriber := p.SubscribeEvents()
+	done := make(chan struct{})
+	var streamErr error
+	go func() {
+		defer close(done)
+		encoder := json.NewEncoder(w)
+		for event := range subscriber {
+			if streamErr == nil {
+				streamErr = encoder.Encode(event.Event)
+			}
+		}
+	}()
+	return func() error {
+		p.UnsubscribeEvents(subscriber)
+		<-done
+		return streamErr
+	}
+}
@@ -0,0 +1,41 @@
+package progress_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestStreamEvents(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1")
+	var buf bytes.Buffer
+	stop := prog.StreamEvents(&buf)
+	prog.AddStep("step2")
+	prog.Get("step1").SetActor("alice").Start()
+	prog.Get("step1").Done()
+	prog.Get("step2").Cancel()
+	require.NoError(t, stop())
+
+	var events []progress.Event
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var event progress.Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.Len(t, events, 4)
+	require.Equal(t, progress.Event{StepID: "step2", To: progress.StateNotStarted, At: events[0].At}, events[0])
+	require.Equal(t, "alice", events[1].Actor)
+	require.Equal(t, progress.StateNotStarted, events[1].From)
+	require.Equal(t, progress.StateInProgress, events[1].To)
+	require.True(t, prog.Get("step1").StartedAt.Equal(events[1].At))
+	require.Equal(t, progress.StateInProgress, events[2].From)
+	require.Equal(t, progress.StateDone, events[2].To)
+	require.True(t, prog.Get("step1").DoneAt.Equal(events[2].At))
+	require.Equal(t, progress.StateCanceled, events[3].To)
+}
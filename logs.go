@@ -0,0 +1,46 @@
+package progress
+
+import (
+	"fmt"
+	"time"
+)
+
+const defaultStepLogLimit = 100
+
+// LogLine is a timestamped message recorded with Step.Logf.
+type LogLine struct {
+	At      time.Time `json:"at" yaml:"at"`
+	Message string    `json:"message" yaml:"message"`
+}
+
+// WithStepLogLimit bounds the log of each step to the 'n' most recent lines, see Step.Logf.
+// The default is 100; values below 1 remove the limit.
+func WithStepLogLimit(n int) Option {
+	return func(p *Progress) {
+		if n < 1 {
+			n = -1
+		}
+		p.stepLogLimit = n
+	}
+}
+
+// Logf appends a timestamped line to the log of the step, formatted like fmt.Sprintf.
+// Only the most recent lines are kept, see WithStepLogLimit.
+// The log travels with the step, i.e., renderers show it when the step fails;
+// use Progress.Redacted with RedactLogs to export a Progress without it.
+func (s *Step) Logf(format string, args ...interface{}) {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	line := LogLine{At: s.parent.now(), Message: fmt.Sprintf(format, args...)}
+	// appended in place: the published copies only see the lines before, see publishedCopy
+	logs := append(s.Logs, line)
+	limit := s.parent.stepLogLimit
+	if limit == 0 {
+		limit = defaultStepLogLimit
+	}
+	if limit > 0 && len(logs) > limit {
+		logs = logs[len(logs)-limit:]
+	}
+	s.Logs = logs
+	s.parent.publishStep(s)
+}
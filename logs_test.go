@@ -0,0 +1,72 @@
+package progress_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progresstest"
+)
+
+func TestStepLogf(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := progresstest.NewClock(start)
+	prog := progress.New(progress.WithClock(clock), progress.WithStepLogLimit(3))
+	step := prog.AddStep("build")
+	for i := 1; i <= 5; i++ {
+		clock.Advance(time.Second)
+		step.Logf("line %d", i)
+	}
+	require.Equal(t, []progress.LogLine{
+		{At: start.Add(3 * time.Second), Message: "line 3"},
+		{At: start.Add(4 * time.Second), Message: "line 4"},
+		{At: start.Add(5 * time.Second), Message: "line 5"},
+	}, step.Logs)
+
+	raw, err := json.Marshal(prog)
+	require.NoError(t, err)
+	loaded := progress.New()
+	require.NoError(t, json.Unmarshal(raw, loaded))
+	require.Equal(t, step.Logs, loaded.MustGet("build").Logs)
+
+	redacted := prog.Redacted(progress.RedactOmit, progress.RedactLogs)
+	require.Nil(t, redacted.MustGet("build").Logs)
+	require.Len(t, step.Logs, 3)
+}
+
+func TestStepLogf_defaultLimit(t *testing.T) {
+	prog := progress.New()
+	step := prog.AddStep("build")
+	for i := 0; i < 150; i++ {
+		step.Logf("line %d", i)
+	}
+	require.Len(t, step.Logs, 100)
+	require.Equal(t, "line 149", step.Logs[99].Message)
+
+	prog = progress.New(progress.WithStepLogLimit(0))
+	step = prog.AddStep("build")
+	for i := 0; i < 150; i++ {
+		step.Logf("%s", fmt.Sprint(i))
+	}
+	require.Len(t, step.Logs, 150)
+}
+
+func TestStepLogf_published(t *testing.T) {
+	prog := progress.New(progress.WithStepLogLimit(0))
+	step := prog.AddStep("build")
+	subscriber := prog.Subscribe()
+	for i := 0; i < 4; i++ {
+		step.Logf("line %d", i)
+	}
+	published := <-subscriber
+	require.Len(t, published.Logs, 1)
+
+	// appending to a published copy does not write into the log of the step
+	published.Logs = append(published.Logs, progress.LogLine{Message: "other"})
+	require.Equal(t, "line 1", step.Logs[1].Message)
+	require.Len(t, (<-subscriber).Logs, 2)
+	require.Len(t, step.Logs, 4)
+}
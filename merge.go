@@ -0,0 +1,99 @@
+package progress
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MergePolicy defines how Progress.Merge handles the steps of the other Progress having the ID of an existing step.
+type MergePolicy int
+
+const (
+	// MergeFail makes Merge return an error wrapping ErrStepIDShouldBeUnique, without merging anything (default).
+	MergeFail MergePolicy = iota
+	// MergeKeep keeps the existing steps, ignoring the conflicting ones.
+	MergeKeep
+	// MergeReplace updates the existing steps with the content of the conflicting ones.
+	MergeReplace
+	// MergeRename adds the conflicting steps with a " #n" suffix, i.e., "build #2".
+	MergeRename
+)
+
+// Merge adds copies of the steps of 'other' to the Progress, in order, with the events recorded for them,
+// so progresses built by independent components can be rendered as one.
+// Steps having the ID of an existing step are handled according to 'policy'.
+// 'other' is left untouched.
+func (p *Progress) Merge(other *Progress, policy MergePolicy) error {
+	steps := other.copySteps()
+	events := other.Events()
+
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	existing := make(map[string]*Step, len(p.Steps)+len(steps))
+	for _, step := range p.Steps {
+		existing[step.ID] = step
+	}
+	if policy == MergeFail {
+		for _, step := range steps {
+			if existing[step.ID] != nil {
+				return fmt.Errorf("%w: %q", ErrStepIDShouldBeUnique, step.ID)
+			}
+		}
+	}
+
+	now := p.now()
+	renamed := make(map[string]string, len(steps))
+	for _, step := range steps {
+		current := existing[step.ID]
+		switch {
+		case current == nil:
+			renamed[step.ID] = step.ID
+		case policy == MergeKeep:
+			continue
+		case policy == MergeReplace:
+			current.replace(step, now)
+			p.publishStep(current)
+			continue
+		case policy == MergeRename:
+			id := step.ID
+			for n := 2; existing[step.ID] != nil; n++ {
+				step.ID = fmt.Sprintf("%s #%d", id, n)
+			}
+			renamed[id] = step.ID
+		}
+		step.parent = p
+		step.doneCh, step.doneChClosed = nil, false
+		existing[step.ID] = step
+		p.Steps = append(p.Steps, step)
+		p.publishStep(step)
+	}
+
+	for _, event := range events {
+		if id, found := renamed[event.StepID]; found {
+			event.StepID = id
+			p.events = append(p.events, event)
+		}
+	}
+	sort.SliceStable(p.events, func(i, j int) bool { return p.events[i].At.Before(p.events[j].At) })
+	p.trimEvents()
+
+	if p.isTerminal() {
+		p.terminate()
+	} else if p.doneChClosed {
+		// the progress is not terminal anymore, next calls to DoneCh will return a fresh chan
+		p.doneCh = nil
+		p.doneChClosed = false
+	}
+	return nil
+}
+
+// replace updates the step with the content of 'other', recording the state transition at 'now'.
+// The caller is responsible for holding the main lock.
+func (s *Step) replace(other *Step, now time.Time) {
+	state, parent, doneCh, doneChClosed := s.State, s.parent, s.doneCh, s.doneChClosed
+	*s = *other
+	s.parent, s.doneCh, s.doneChClosed = parent, doneCh, doneChClosed
+	s.State = state
+	s.setState(other.State, now)
+}
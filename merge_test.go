@@ -0,0 +1,64 @@
+package progress_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func newMergeSources() (*progress.Progress, *progress.Progress) {
+	prog := progress.New()
+	prog.AddStep("fetch").Done()
+	prog.AddStep("build").Start()
+
+	other := progress.New()
+	other.AddStep("build").SetDescription("other build").Done()
+	other.AddStep("upload").Start()
+	return prog, other
+}
+
+func TestMerge(t *testing.T) {
+	prog, other := newMergeSources()
+	err := prog.Merge(other, progress.MergeFail)
+	require.ErrorIs(t, err, progress.ErrStepIDShouldBeUnique)
+	require.Len(t, prog.Steps, 2)
+
+	require.NoError(t, prog.Merge(other, progress.MergeKeep))
+	require.Equal(t, []string{"fetch", "build", "upload"}, stepIDs(prog.Steps))
+	require.Equal(t, progress.StateInProgress, prog.MustGet("build").State)
+	require.Equal(t, progress.StateInProgress, prog.MustGet("upload").State)
+
+	// the merged steps belong to the Progress
+	prog.MustGet("upload").Done()
+	require.Equal(t, progress.StateInProgress, other.MustGet("upload").State)
+	require.Equal(t, 2, prog.Snapshot().Completed)
+
+	var uploadEvents int
+	for _, event := range prog.Events() {
+		if event.StepID == "upload" {
+			uploadEvents++
+		}
+	}
+	require.Equal(t, 3, uploadEvents)
+}
+
+func TestMerge_replace(t *testing.T) {
+	prog, other := newMergeSources()
+	build := prog.MustGet("build")
+	require.NoError(t, prog.Merge(other, progress.MergeReplace))
+	require.Equal(t, []string{"fetch", "build", "upload"}, stepIDs(prog.Steps))
+	require.Same(t, build, prog.MustGet("build"))
+	require.Equal(t, "other build", build.Description)
+	require.Equal(t, progress.StateDone, build.State)
+	events := prog.Events()
+	require.Contains(t, events, progress.Event{StepID: "build", From: progress.StateInProgress, To: progress.StateDone, At: events[len(events)-1].At})
+}
+
+func TestMerge_rename(t *testing.T) {
+	prog, other := newMergeSources()
+	require.NoError(t, prog.Merge(other, progress.MergeRename))
+	require.NoError(t, prog.Merge(other, progress.MergeRename))
+	require.Equal(t, []string{"fetch", "build", "build #2", "upload", "build #3", "upload #2"}, stepIDs(prog.Steps))
+	require.Equal(t, "other build", prog.MustGet("build #3").Description)
+}
@@ -0,0 +1,26 @@
+package progress
+
+import "maps"
+
+// SetMeta sets the metadata 'key' of the step to 'value', see Step.Metadata.
+// It returns itself (*Step) for chaining.
+func (s *Step) SetMeta(key, value string) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	// copy on write, the published copies of the step share the previous map
+	metadata := maps.Clone(s.Metadata)
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	metadata[key] = value
+	s.Metadata = metadata
+	s.parent.publishStep(s)
+	return s
+}
+
+// Meta returns the metadata 'key' of the step, or an empty string if it is not set.
+func (s *Step) Meta(key string) string {
+	s.parent.mainMutex.RLock()
+	defer s.parent.mainMutex.RUnlock()
+	return s.Metadata[key]
+}
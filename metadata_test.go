@@ -0,0 +1,29 @@
+package progress_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestMetadata(t *testing.T) {
+	prog := progress.New()
+	step := prog.AddStep("deploy").SetMeta("region", "eu-west-1").SetMeta("host", "web-1")
+	require.Equal(t, "eu-west-1", step.Meta("region"))
+	require.Empty(t, step.Meta("shard"))
+
+	sub := prog.Subscribe()
+	step.SetMeta("host", "web-2")
+	published := <-sub
+	step.SetMeta("shard", "3")
+	require.Equal(t, map[string]string{"region": "eu-west-1", "host": "web-2"}, published.Metadata)
+	prog.Unsubscribe(sub)
+
+	raw, err := json.Marshal(prog)
+	require.NoError(t, err)
+	loaded := progress.New()
+	require.NoError(t, json.Unmarshal(raw, loaded))
+	require.Equal(t, "3", loaded.MustGet("deploy").Meta("shard"))
+}
@@ -0,0 +1,51 @@
+package progress
+
+import (
+	"context"
+	"time"
+)
+
+// Option configures a Progress created with New.
+type Option func(*Progress)
+
+// WithClock makes the Progress use 'clock' instead of the system clock,
+// i.e., to get deterministic timestamps in tests or to run simulations.
+func WithClock(clock Clock) Option {
+	return func(p *Progress) { p.clock = clock }
+}
+
+// WithCapacity preallocates room for 'n' steps.
+func WithCapacity(n int) Option {
+	return func(p *Progress) { p.Steps = make([]*Step, 0, n) }
+}
+
+// WithSafeMode makes the invalid step transitions, i.e., calling Done on a done step, noops instead of panicking,
+// so a bookkeeping mistake cannot crash a program in production.
+func WithSafeMode() Option {
+	return func(p *Progress) { p.safeMode = true }
+}
+
+// WithPercentStrategy configures how the completion rate of the Progress is computed, see PercentStrategy.
+func WithPercentStrategy(strategy PercentStrategy) Option {
+	return func(p *Progress) { p.percentStrategy = strategy }
+}
+
+// WithAutoSave keeps the version of the Progress saved in 'store' with 'id' up to date, like AttachStore,
+// until Close is called. Save errors are ignored; use AttachStore directly to handle them.
+func WithAutoSave(store Store, id string, interval time.Duration) Option {
+	return func(p *Progress) {
+		p.autoSave = func() func() error { return p.AttachStore(context.Background(), store, id, interval) }
+	}
+}
+
+// PercentStrategy defines how the completion rate of a Progress is computed.
+type PercentStrategy int
+
+const (
+	// PercentSteps gives the same weight to each step, and counts the steps in progress
+	// partially based on Step.Progress (default).
+	PercentSteps PercentStrategy = iota
+	// PercentCompletedSteps only counts the done and skipped steps, ignoring the progress of the
+	// steps in progress.
+	PercentCompletedSteps
+)
@@ -0,0 +1,54 @@
+package progress_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestWithCapacity(t *testing.T) {
+	prog := progress.New(progress.WithCapacity(10))
+	require.Empty(t, prog.Steps)
+	require.Equal(t, 10, cap(prog.Steps))
+	prog.AddSteps("a", "b")
+	require.Len(t, prog.Steps, 2)
+}
+
+func TestWithSafeMode(t *testing.T) {
+	require.Panics(t, func() { progress.New().AddStep("a").Done().Done() })
+
+	prog := progress.New(progress.WithSafeMode())
+	step := prog.AddStep("a").Done()
+	doneAt := step.DoneAt
+	require.NotPanics(t, func() {
+		step.Done()
+		step.Start()
+		step.Cancel()
+		step.Skip("")
+	})
+	require.Equal(t, progress.StateDone, step.State)
+	require.Equal(t, doneAt, step.DoneAt)
+}
+
+func TestWithPercentStrategy(t *testing.T) {
+	prog := progress.New(progress.WithPercentStrategy(progress.PercentCompletedSteps))
+	prog.AddSteps("a", "b")
+	prog.MustGet("a").SetProgress(0.5)
+	require.Equal(t, 0.0, prog.Progress())
+	prog.MustGet("b").Done()
+	require.Equal(t, 0.5, prog.Progress())
+}
+
+func TestWithAutoSave(t *testing.T) {
+	store := memoryStore{}
+	prog := progress.New(progress.WithAutoSave(store, "job", 0))
+	prog.AddSteps("a", "b")
+	prog.MustGet("a").Done()
+	prog.Close()
+
+	loaded, err := store.Load(context.Background(), "job")
+	require.NoError(t, err)
+	require.Equal(t, prog, loaded)
+}
@@ -0,0 +1,34 @@
+package progress
+
+// Outcome summarizes how the steps of a terminal Progress ended, including mixed outcomes, see Snapshot.Outcome.
+type Outcome string
+
+const (
+	// OutcomeNone is the outcome of a Progress having steps not terminal yet.
+	OutcomeNone Outcome = ""
+	// OutcomeSuccess is the outcome of a Progress whose steps are all done or skipped.
+	OutcomeSuccess Outcome = "success"
+	// OutcomePartial is the outcome of a Progress having done steps, and failed or canceled steps.
+	OutcomePartial Outcome = "done with failures"
+	// OutcomeFailure is the outcome of a Progress having failed steps and no done step.
+	OutcomeFailure Outcome = "failure"
+	// OutcomeCanceled is the outcome of a Progress having canceled steps, no failed step, and no done step.
+	OutcomeCanceled Outcome = "canceled"
+)
+
+// outcome computes the outcome of the snapshot based on its counts.
+func (s Snapshot) outcome() Outcome {
+	if s.Total == 0 || s.NotStarted+s.InProgress+s.Interrupted > 0 {
+		return OutcomeNone
+	}
+	switch {
+	case s.Failed == 0 && s.Canceled == 0:
+		return OutcomeSuccess
+	case s.Completed > 0:
+		return OutcomePartial
+	case s.Failed > 0:
+		return OutcomeFailure
+	default:
+		return OutcomeCanceled
+	}
+}
@@ -0,0 +1,49 @@
+package progress_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestSnapshot_Outcome(t *testing.T) {
+	prog := progress.New()
+	require.Equal(t, progress.OutcomeNone, prog.Snapshot().Outcome)
+	steps := prog.AddSteps("a", "b", "c", "d")
+	steps[0].Done()
+	steps[1].Fail(errors.New("oops"))
+	steps[2].Cancel()
+	snapshot := prog.Snapshot()
+	require.Equal(t, progress.StateStopped, snapshot.State)
+	require.Equal(t, progress.OutcomeNone, snapshot.Outcome)
+	require.Equal(t, 1, snapshot.Completed)
+	require.Equal(t, 1, snapshot.Failed)
+	require.Equal(t, 1, snapshot.Canceled)
+	require.Equal(t, 1, snapshot.NotStarted)
+
+	steps[3].Skip("")
+	snapshot = prog.Snapshot()
+	require.Equal(t, progress.StateFailed, snapshot.State)
+	require.Equal(t, progress.OutcomePartial, snapshot.Outcome)
+	require.Equal(t, 1, snapshot.Skipped)
+	require.Contains(t, snapshot.String(), "1/4 (50%) — done with failures")
+
+	for _, test := range []struct {
+		name     string
+		finish   func(*progress.Step)
+		expected progress.Outcome
+	}{
+		{"success", func(step *progress.Step) { step.Skip("") }, progress.OutcomeSuccess},
+		{"failure", func(step *progress.Step) { step.Fail(nil) }, progress.OutcomeFailure},
+		{"canceled", func(step *progress.Step) { step.Cancel() }, progress.OutcomeCanceled},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			prog := progress.New()
+			prog.AddStep("a").Skip("")
+			test.finish(prog.AddStep("b"))
+			require.Equal(t, test.expected, prog.Snapshot().Outcome)
+		})
+	}
+}
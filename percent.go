@@ -0,0 +1,96 @@
+package progress
+
+import (
+	"math"
+	"strconv"
+)
+
+// PercentRounding defines how completion percentages are rounded, see PercentFormat.
+type PercentRounding int
+
+const (
+	// PercentFloor rounds the percentages down, so a step is never reported as done too early (default).
+	PercentFloor PercentRounding = iota
+	// PercentRound rounds the percentages to the nearest value.
+	PercentRound
+)
+
+// PercentFormat configures the completion percentages returned by Snapshot.Percent, and used by the text
+// representations of the Progress.
+//
+// Whatever the format, 100% is only reported once every step is terminal.
+type PercentFormat struct {
+	// Precision is the number of decimals, between 0 (default) and 2.
+	Precision int
+	// Rounding is the rounding mode, PercentFloor by default.
+	Rounding PercentRounding
+}
+
+// WithPercentFormat configures the precision and rounding of the completion percentages, see PercentFormat.
+func WithPercentFormat(format PercentFormat) Option {
+	return func(p *Progress) { p.percentFormat = format }
+}
+
+// Format formats 'ratio', a completion rate between 0 and 1, as a percentage with its sign, i.e., "42%".
+// 100% is only reported if 'terminal' is true, see PercentFormat.
+func (f PercentFormat) Format(ratio float64, terminal bool) string {
+	return string(append(f.append(nil, ratio, terminal), '%'))
+}
+
+// percent returns 'ratio' as a percentage rounded according to the format, see Format.
+func (f PercentFormat) percent(ratio float64, terminal bool) float64 {
+	scale := math.Pow10(f.precision())
+	value := ratio * 100 * scale
+	switch f.Rounding {
+	case PercentRound:
+		value = math.Round(value)
+	default:
+		// tolerate the float errors of the sum of the step rates, i.e., 0.58 * 100 = 57.99999999999999
+		value = math.Floor(value + 1e-9)
+	}
+	if !terminal && value >= 100*scale {
+		value = 100*scale - 1
+	}
+	return max(value, 0) / scale
+}
+
+// append appends 'ratio' as a percentage with the precision of the format, without the % sign.
+func (f PercentFormat) append(b []byte, ratio float64, terminal bool) []byte {
+	return strconv.AppendFloat(b, f.percent(ratio, terminal), 'f', f.precision(), 64)
+}
+
+func (f PercentFormat) precision() int {
+	return min(max(f.Precision, 0), 2)
+}
+
+// PercentFormat returns the format of the completion percentages of the Progress, see WithPercentFormat.
+func (p *Progress) PercentFormat() PercentFormat {
+	return p.percentFormat
+}
+
+// Percent returns the completion rate of the snapshot as a percentage, rounded according to the
+// PercentFormat of its Progress.
+func (s Snapshot) Percent() float64 {
+	return s.percentFormat.percent(s.Progress, s.isComplete())
+}
+
+// FormatPercent returns the completion rate of the snapshot as a percentage with its sign, i.e., "42%",
+// formatted according to the PercentFormat of its Progress.
+func (s Snapshot) FormatPercent() string {
+	return s.percentFormat.Format(s.Progress, s.isComplete())
+}
+
+// Percent returns the current completion rate as a percentage, see Snapshot.Percent.
+func (p *Progress) Percent() float64 {
+	return p.Snapshot().Percent()
+}
+
+// appendPercent appends the percentage of the snapshot with its configured precision, without the % sign.
+func (s Snapshot) appendPercent(b []byte) []byte {
+	return s.percentFormat.append(b, s.Progress, s.isComplete())
+}
+
+// isComplete returns true if no step is pending or in progress anymore.
+func (s Snapshot) isComplete() bool {
+	return s.NotStarted+s.InProgress+s.Interrupted == 0
+}
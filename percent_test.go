@@ -0,0 +1,55 @@
+package progress_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestPercent(t *testing.T) {
+	newProgress := func(opts ...progress.Option) *progress.Progress {
+		prog := progress.New(opts...)
+		prog.AddStep("a").Done()
+		prog.AddStep("b").Done()
+		prog.AddStep("c").Start().SetProgress(0.999)
+		return prog
+	}
+
+	prog := newProgress()
+	require.Equal(t, 99.0, prog.Percent())
+	text, err := prog.MarshalText()
+	require.NoError(t, err)
+	require.Contains(t, string(text), " 99% ")
+
+	prog = newProgress(progress.WithPercentFormat(progress.PercentFormat{Precision: 2}))
+	require.Equal(t, 99.96, prog.Percent())
+	require.Contains(t, prog.Snapshot().String(), "(99.96%)")
+
+	// 100% is only reached once every step is terminal
+	prog = newProgress(progress.WithPercentFormat(progress.PercentFormat{Precision: 1, Rounding: progress.PercentRound}))
+	require.Equal(t, 99.9, prog.Percent())
+	prog.MustGet("c").Done()
+	require.Equal(t, 100.0, prog.Percent())
+
+	prog = progress.New(progress.WithPercentFormat(progress.PercentFormat{Rounding: progress.PercentRound}))
+	prog.AddStep("a").Done()
+	prog.AddStep("b").Done()
+	prog.AddStep("c")
+	require.Equal(t, 67.0, prog.Percent())
+	require.Equal(t, 0.0, progress.New().Percent())
+}
+
+func TestPercentFormat_Format(t *testing.T) {
+	format := progress.PercentFormat{Precision: 1, Rounding: progress.PercentRound}
+	require.Equal(t, "42.5%", format.Format(0.4249, false))
+	require.Equal(t, "99.9%", format.Format(0.99999, false))
+	require.Equal(t, "100.0%", format.Format(0.99999, true))
+	require.Equal(t, "0%", progress.PercentFormat{}.Format(-1, false))
+
+	prog := progress.New(progress.WithPercentFormat(format))
+	require.Equal(t, format, prog.PercentFormat())
+	prog.AddStep("a").Done()
+	prog.AddStep("b")
+	require.Equal(t, "50.0%", prog.Snapshot().FormatPercent())
+}
@@ -0,0 +1,74 @@
+package progress
+
+// PlannedStep describes the execution of a step by Progress.Run, as resolved by Progress.Plan.
+type PlannedStep struct {
+	ID           string   `json:"id" yaml:"id"`
+	Description  string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Dependencies []string `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	// Wave is the depth of the step in the dependency graph: the steps of a wave can run in parallel
+	// once the steps of the previous waves are done.
+	Wave int `json:"wave" yaml:"wave"`
+	// BlockedBy is the ID of the dependency preventing the step from being executed, if any.
+	BlockedBy string `json:"blocked_by,omitempty" yaml:"blocked_by,omitempty"`
+	// Skip is the reason of the step being skipped, if its SkipIf condition is currently met.
+	Skip string `json:"skip,omitempty" yaml:"skip,omitempty"`
+}
+
+// Plan returns the steps that Progress.Run would execute, in the order of a sequential run,
+// assuming every step function succeeds. Nothing is executed, but the SkipIf conditions are evaluated.
+// Like Run, it returns an error if a dependency is missing or cyclic.
+func (p *Progress) Plan() ([]PlannedStep, error) {
+	steps := p.runnableSteps()
+	sched, err := p.newSchedule(steps)
+	if err != nil {
+		return nil, err
+	}
+
+	// the conditions are evaluated without holding the lock, so they can inspect the Progress
+	skips := make(map[*Step]string)
+	for _, step := range steps {
+		p.mainMutex.RLock()
+		cond, reason := step.skipIf, step.skipReason
+		p.mainMutex.RUnlock()
+		if cond != nil && cond() {
+			skips[step] = reason
+		}
+	}
+
+	p.mainMutex.RLock()
+	defer p.mainMutex.RUnlock()
+	waves := make(map[*Step]int, len(steps))
+	ret := make([]PlannedStep, 0, len(steps))
+	pending := steps
+	for len(pending) > 0 {
+		// like Run, cancel the blocked steps before starting the next ready one
+		var planned PlannedStep
+		idx := -1
+		for candidate, step := range pending {
+			if blocker := sched.blockingDependency(step); blocker != nil {
+				idx, planned.BlockedBy = candidate, blocker.ID
+				break
+			}
+		}
+		if idx < 0 {
+			idx = sched.nextReady(pending, nil)
+		}
+		step := pending[idx]
+		pending = append(pending[:idx:idx], pending[idx+1:]...)
+		sched.finished[step] = planned.BlockedBy == ""
+		for _, dep := range sched.deps[step] {
+			if wave, ok := waves[dep]; ok && wave+1 > planned.Wave {
+				planned.Wave = wave + 1
+			}
+		}
+		waves[step] = planned.Wave
+		planned.ID = step.ID
+		planned.Description = step.Description
+		if planned.BlockedBy == "" {
+			planned.Skip = skips[step]
+		}
+		planned.Dependencies = append([]string(nil), step.dependencies...)
+		ret = append(ret, planned)
+	}
+	return ret, nil
+}
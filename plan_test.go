@@ -0,0 +1,42 @@
+package progress_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestPlan(t *testing.T) {
+	prog := progress.New()
+	called := false
+	fn := func(context.Context, *progress.Step) error {
+		called = true
+		return nil
+	}
+	prog.AddStep("deploy").SetFunc(fn).DependsOn("test", "build")
+	prog.AddStep("test").SetFunc(fn).DependsOn("build")
+	prog.AddStep("lint").SetFunc(fn)
+	prog.AddStep("build").SetFunc(fn).SetDescription("compile")
+	prog.AddStep("manual")
+	prog.AddStep("publish").SetFunc(fn).DependsOn("manual")
+	prog.AddStep("announce").SetFunc(fn).DependsOn("publish")
+
+	plan, err := prog.Plan()
+	require.NoError(t, err)
+	require.False(t, called)
+	require.Equal(t, progress.StateNotStarted, prog.Snapshot().State)
+	require.Equal(t, []progress.PlannedStep{
+		{ID: "publish", Dependencies: []string{"manual"}, BlockedBy: "manual"},
+		{ID: "announce", Dependencies: []string{"publish"}, Wave: 1, BlockedBy: "publish"},
+		{ID: "lint"},
+		{ID: "build", Description: "compile"},
+		{ID: "test", Dependencies: []string{"build"}, Wave: 1},
+		{ID: "deploy", Dependencies: []string{"test", "build"}, Wave: 2},
+	}, plan)
+
+	prog.AddStep("cycle").SetFunc(fn).DependsOn("cycle")
+	_, err = prog.Plan()
+	require.ErrorIs(t, err, progress.ErrDependencyCycle)
+}
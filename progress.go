@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
 	"sync"
 	"time"
 
@@ -13,22 +12,56 @@ import (
 
 // Progress is the top-level object of the 'progress' library.
 type Progress struct {
-	Steps     []*Step   `json:"steps,omitempty"`
-	CreatedAt time.Time `json:"created_at,omitempty"`
+	Steps     []*Step   `json:"steps,omitempty" yaml:"steps,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty" yaml:"created_at,omitempty"`
 
-	mainMutex   sync.RWMutex
-	subscribers map[chan *Step]struct{}
+	mainMutex       sync.RWMutex
+	subscribers     map[chan *Step][]SubscribeFilter
+	eventSubs       map[chan StepEvent]struct{}
+	pendingEvents   []Event
+	events          []Event
+	eventLogLimit   int
+	doneCh          chan struct{}
+	doneChClosed    bool
+	revision        uint64
+	dataCodec       DataCodec
+	durationFormat  DurationFormat
+	clock           Clock
+	safeMode        bool
+	percentStrategy PercentStrategy
+	strictMode      bool
+	strictHandler   func(err error)
+	stepLogLimit    int
+	autoSave        func() (stop func() error)
+	stopAutoSave    func() error
+	doingFormat     DoingFormat
+	humanDurations  bool
+	percentFormat   PercentFormat
+	expectedTotal   int
+	indeterminate   bool
+	autoAdvance     bool
+	staleThreshold  time.Duration
+	lastTransition  time.Time
 }
 
 type State string
 
 const (
-	StateNotStarted State = "not started"
-	StateInProgress State = "in progress"
-	StateDone       State = "done"
-	StateStopped    State = "stopped"
+	StateNotStarted  State = "not started"
+	StateInProgress  State = "in progress"
+	StateDone        State = "done"
+	StateStopped     State = "stopped"
+	StateCanceled    State = "canceled"
+	StateInterrupted State = "interrupted"
+	StateFailed      State = "failed"
+	StateSkipped     State = "skipped"
 )
 
+// IsTerminal returns true if no further transition is expected from this state.
+func (s State) IsTerminal() bool {
+	return s == StateDone || s == StateCanceled || s == StateFailed || s == StateSkipped
+}
+
 const (
 	notStartedProgress   = 0.0
 	defaultStartProgress = 0.5
@@ -39,11 +72,17 @@ const (
 	defaultSubscriberChanLength = 42
 )
 
-// New creates and returns a new Progress.
-func New() *Progress {
-	return &Progress{
-		CreatedAt: time.Now(),
+// New creates and returns a new Progress configured with 'opts'.
+func New(opts ...Option) *Progress {
+	p := &Progress{}
+	for _, opt := range opts {
+		opt(p)
 	}
+	p.CreatedAt = p.now()
+	if p.autoSave != nil {
+		p.stopAutoSave = p.autoSave()
+	}
+	return p
 }
 
 // AddStep creates and returns a new Step with the provided 'id'.
@@ -58,46 +97,99 @@ func (p *Progress) AddStep(id string) *Step {
 
 // SafeAddStep is equivalent to AddStep with but returns error instead of panicking.
 func (p *Progress) SafeAddStep(id string) (*Step, error) {
-	if id == "" {
-		return nil, ErrStepRequiresID
+	steps, err := p.AddStepsFrom([]StepDef{{ID: id}})
+	if err != nil {
+		return nil, err
+	}
+	return steps[0], nil
+}
+
+// StepDef describes a step created by AddStepsFrom.
+type StepDef struct {
+	ID          string      `json:"id" yaml:"id"`
+	Description string      `json:"description,omitempty" yaml:"description,omitempty"`
+	Data        interface{} `json:"data,omitempty" yaml:"data,omitempty"`
+}
+
+// AddSteps creates and returns a new Step for each of the provided 'ids', in order.
+// Non-empty, unique 'ids' are required, else it will panic.
+func (p *Progress) AddSteps(ids ...string) []*Step {
+	defs := make([]StepDef, len(ids))
+	for idx, id := range ids {
+		defs[idx].ID = id
 	}
-	step := &Step{
-		ID:       id,
-		State:    StateNotStarted,
-		Progress: notStartedProgress,
-		parent:   p,
+	steps, err := p.AddStepsFrom(defs)
+	if err != nil {
+		panic(err)
 	}
+	return steps
+}
 
+// AddStepsFrom creates and returns a new Step for each of the provided definitions, in order.
+// It returns an error without creating any step if an ID is empty or not unique.
+func (p *Progress) AddStepsFrom(defs []StepDef) ([]*Step, error) {
 	p.mainMutex.Lock()
 	defer p.mainMutex.Unlock()
-	if p.Steps == nil {
-		p.Steps = make([]*Step, 0)
-	}
 
+	seen := make(map[string]bool, len(p.Steps)+len(defs))
 	for _, step := range p.Steps {
-		if step.ID == id {
+		seen[step.ID] = true
+	}
+	for _, def := range defs {
+		if def.ID == "" {
+			return nil, ErrStepRequiresID
+		}
+		if seen[def.ID] {
 			return nil, ErrStepIDShouldBeUnique
 		}
+		seen[def.ID] = true
 	}
 
-	p.Steps = append(p.Steps, step)
-	p.publishStep(step)
-	return step, nil
+	if p.Steps == nil {
+		p.Steps = make([]*Step, 0, len(defs))
+	}
+	if len(defs) > 0 && p.doneChClosed {
+		// the progress is not terminal anymore, next calls to DoneCh will return a fresh chan
+		p.doneCh = nil
+		p.doneChClosed = false
+	}
+	now := p.now()
+	steps := make([]*Step, 0, len(defs))
+	for _, def := range defs {
+		step := &Step{
+			ID:          def.ID,
+			Description: def.Description,
+			Data:        def.Data,
+			State:       StateNotStarted,
+			Progress:    notStartedProgress,
+			parent:      p,
+		}
+		p.Steps = append(p.Steps, step)
+		p.recordEvent(step, "", StateNotStarted, now)
+		p.publishStep(step)
+		steps = append(steps, step)
+	}
+	return steps, nil
 }
 
 // publishStep iterates over subscribers and try to append a step.
+// The caller is responsible for holding the main lock.
 func (p *Progress) publishStep(step *Step) {
+	p.revision++
+	p.publishEvents(step)
 	if len(p.subscribers) == 0 {
 		return
 	}
 
 	var stepCopyPtr *Step
 	if step != nil {
-		stepCopy := *step
-		stepCopyPtr = &stepCopy
+		stepCopyPtr = step.publishedCopy()
 	}
 
-	for subscriber := range p.subscribers {
+	for subscriber, filters := range p.subscribers {
+		if !matchFilters(stepCopyPtr, filters) {
+			continue
+		}
 		select {
 		case subscriber <- stepCopyPtr:
 		case <-time.After(publishTimeout):
@@ -106,21 +198,76 @@ func (p *Progress) publishStep(step *Step) {
 	}
 }
 
-// Subscribe registers the provided chan as a target called each time a step is changed.
-func (p *Progress) Subscribe() chan *Step {
+// SubscribeFilter decides whether a changed step should be sent to a subscriber.
+type SubscribeFilter func(step *Step) bool
+
+// FilterStates returns a SubscribeFilter only matching steps in one of the provided states.
+func FilterStates(states ...State) SubscribeFilter {
+	return func(step *Step) bool {
+		for _, state := range states {
+			if step.State == state {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func matchFilters(step *Step, filters []SubscribeFilter) bool {
+	if step == nil {
+		return true
+	}
+	for _, filter := range filters {
+		if !filter(step) {
+			return false
+		}
+	}
+	return true
+}
+
+// Subscribe returns a new chan receiving a copy of each changed step.
+// If filters are provided, only the steps matching all of them are sent.
+// The chan is closed when the Progress becomes terminal, on Unsubscribe, or on Close.
+func (p *Progress) Subscribe(filters ...SubscribeFilter) chan *Step {
 	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	return p.subscribe(filters)
+}
+
+// subscribe registers a new subscriber.
+// The caller is responsible for holding the main lock.
+func (p *Progress) subscribe(filters []SubscribeFilter) chan *Step {
 	subscriber := make(chan *Step, defaultSubscriberChanLength)
 	if p.subscribers == nil {
-		p.subscribers = make(map[chan *Step]struct{})
+		p.subscribers = make(map[chan *Step][]SubscribeFilter)
 	}
-	p.subscribers[subscriber] = struct{}{}
-	p.mainMutex.Unlock()
+	p.subscribers[subscriber] = filters
 	return subscriber
 }
 
+// Unsubscribe unregisters and closes a chan returned by Subscribe.
+// Calling it on an already closed subscriber is a noop.
+func (p *Progress) Unsubscribe(subscriber chan *Step) {
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	if _, found := p.subscribers[subscriber]; !found {
+		return
+	}
+	close(subscriber)
+	delete(p.subscribers, subscriber)
+}
+
 // Close cleans up the allocated ressources.
+// With WithAutoSave, the Progress is saved a last time.
 func (p *Progress) Close() {
+	p.mainMutex.Lock()
+	stopAutoSave := p.stopAutoSave
+	p.stopAutoSave = nil
 	p.closeSubscribers()
+	p.mainMutex.Unlock()
+	if stopAutoSave != nil {
+		_ = stopAutoSave()
+	}
 }
 
 func (p *Progress) closeSubscribers() {
@@ -128,6 +275,11 @@ func (p *Progress) closeSubscribers() {
 		close(sub)
 		delete(p.subscribers, sub)
 	}
+	p.publishEvents(nil)
+	for sub := range p.eventSubs {
+		close(sub)
+		delete(p.eventSubs, sub)
+	}
 }
 
 // Get retrieves a Step by its 'id'.
@@ -150,47 +302,130 @@ func (p *Progress) Get(id string) *Step {
 	return nil
 }
 
+// GetE is equivalent to Get but returns an error instead of panicking on an empty 'id',
+// and an error wrapping ErrStepNotFound instead of nil if 'id' does not match an existing step.
+func (p *Progress) GetE(id string) (*Step, error) {
+	if id == "" {
+		return nil, ErrStepRequiresID
+	}
+	if step := p.Get(id); step != nil {
+		return step, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrStepNotFound, id)
+}
+
+// MustGet is equivalent to Get but panics if 'id' does not match an existing step,
+// so a mistyped ID fails where the mistake is made.
+func (p *Progress) MustGet(id string) *Step {
+	step, err := p.GetE(id)
+	if err != nil {
+		panic(err)
+	}
+	return step
+}
+
 // Snapshot represents info and stats about a progress at a given time.
 type Snapshot struct {
-	State              State         `json:"state,omitempty"`
-	Doing              string        `json:"doing,omitempty"`
-	NotStarted         int           `json:"not_started,omitempty"`
-	InProgress         int           `json:"in_progress,omitempty"`
-	Completed          int           `json:"completed,omitempty"`
-	Total              int           `json:"total,omitempty"`
-	Progress           float64       `json:"progress,omitempty"`
-	TotalDuration      time.Duration `json:"total_duration,omitempty"`
-	StepDuration       time.Duration `json:"step_duration,omitempty"`
-	CompletionEstimate time.Duration `json:"completion_estimate,omitempty"`
-	DoneAt             *time.Time    `json:"done_at,omitempty"`
-	StartedAt          *time.Time    `json:"started_at,omitempty"`
+	State              State         `json:"state,omitempty" yaml:"state,omitempty"`
+	Doing              string        `json:"doing,omitempty" yaml:"doing,omitempty"`
+	NotStarted         int           `json:"not_started,omitempty" yaml:"not_started,omitempty"`
+	InProgress         int           `json:"in_progress,omitempty" yaml:"in_progress,omitempty"`
+	Completed          int           `json:"completed,omitempty" yaml:"completed,omitempty"`
+	Canceled           int           `json:"canceled,omitempty" yaml:"canceled,omitempty"`
+	Interrupted        int           `json:"interrupted,omitempty" yaml:"interrupted,omitempty"`
+	Failed             int           `json:"failed,omitempty" yaml:"failed,omitempty"`
+	Skipped            int           `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+	Total              int           `json:"total,omitempty" yaml:"total,omitempty"`
+	Progress           float64       `json:"progress,omitempty" yaml:"progress,omitempty"`
+	TotalDuration      time.Duration `json:"total_duration,omitempty" yaml:"total_duration,omitempty"`
+	StepDuration       time.Duration `json:"step_duration,omitempty" yaml:"step_duration,omitempty"`
+	CompletionEstimate time.Duration `json:"completion_estimate,omitempty" yaml:"completion_estimate,omitempty"`
+	DoneAt             *time.Time    `json:"done_at,omitempty" yaml:"done_at,omitempty"`
+	StartedAt          *time.Time    `json:"started_at,omitempty" yaml:"started_at,omitempty"`
+	// Outcome summarizes the outcome of the steps once they are all terminal, i.e., OutcomePartial if some of
+	// them failed, while State reports the first matching of failed, canceled, or done.
+	Outcome Outcome `json:"outcome,omitempty" yaml:"outcome,omitempty"`
+	// Stale is set if at least one step is in progress for longer than its staleness threshold,
+	// see WithStaleThreshold; StaleSteps contains their IDs.
+	Stale      bool     `json:"stale,omitempty" yaml:"stale,omitempty"`
+	StaleSteps []string `json:"stale_steps,omitempty" yaml:"stale_steps,omitempty"`
+	// Indeterminate is set while the completion rate is unknown, see Progress.SetIndeterminate;
+	// Progress and CompletionEstimate are then zero.
+	Indeterminate bool `json:"indeterminate,omitempty" yaml:"indeterminate,omitempty"`
+	// ByTag contains the snapshots of the steps having each tag, see Progress.SnapshotFor.
+	ByTag map[string]Snapshot `json:"by_tag,omitempty" yaml:"by_tag,omitempty"`
+	// Groups summarizes the steps of each group, in order of appearance, see Step.SetGroup.
+	Groups []GroupSummary `json:"groups,omitempty" yaml:"groups,omitempty"`
+	// Children contains the snapshots of the children of the Progress by step ID, see Progress.AddChild.
+	Children map[string]Snapshot `json:"children,omitempty" yaml:"children,omitempty"`
+
+	durationFormat DurationFormat
+	humanDurations bool
+	percentFormat  PercentFormat
 }
 
 // Snapshot computes and returns the current stats of the Progress.
 func (p *Progress) Snapshot() Snapshot {
 	p.mainMutex.RLock()
-	defer p.mainMutex.RUnlock()
-	if len(p.Steps) == 0 {
+	snapshot := p.snapshot(p.Steps, p.total())
+	snapshot.ByTag = p.snapshotsByTag()
+	snapshot.Groups = p.groupSummaries()
+	p.mainMutex.RUnlock()
+	for id, child := range p.children() {
+		if snapshot.Children == nil {
+			snapshot.Children = make(map[string]Snapshot)
+		}
+		snapshot.Children[id] = child.Snapshot()
+	}
+	return snapshot
+}
+
+// snapshot computes the stats of 'steps', out of 'total' steps, the missing ones being not started yet.
+// The caller is responsible for holding the main lock.
+func (p *Progress) snapshot(steps []*Step, total int) Snapshot {
+	if len(steps) == 0 {
 		return Snapshot{
-			State: StateNotStarted,
+			State:          StateNotStarted,
+			NotStarted:     total,
+			Total:          total,
+			Indeterminate:  p.indeterminate,
+			durationFormat: p.durationFormat,
+			humanDurations: p.humanDurations,
+			percentFormat:  p.percentFormat,
 		}
 	}
 
 	snapshot := Snapshot{
-		Total:    len(p.Steps),
-		Progress: 0,
+		Total:          total,
+		NotStarted:     total - len(steps),
+		Progress:       0,
+		durationFormat: p.durationFormat,
+		humanDurations: p.humanDurations,
+		percentFormat:  p.percentFormat,
 	}
 
-	doing := []string{}
-	for _, step := range p.Steps {
+	doing := []*Step{}
+	for _, step := range steps {
 		switch step.State {
 		case StateNotStarted:
 			snapshot.NotStarted++
 		case StateInProgress:
 			snapshot.InProgress++
-			doing = append(doing, step.title())
+			doing = append(doing, step)
+			if step.isStale() {
+				snapshot.Stale = true
+				snapshot.StaleSteps = append(snapshot.StaleSteps, step.ID)
+			}
 		case StateDone:
 			snapshot.Completed++
+		case StateCanceled:
+			snapshot.Canceled++
+		case StateInterrupted:
+			snapshot.Interrupted++
+		case StateFailed:
+			snapshot.Failed++
+		case StateSkipped:
+			snapshot.Skipped++
 		case StateStopped:
 			panic(fmt.Sprintf("step cannot be in stopped state (yet!): %s", u.JSON(step)))
 		default:
@@ -216,41 +451,73 @@ func (p *Progress) Snapshot() Snapshot {
 		}
 	}
 
-	snapshot.Progress = p.Progress()
+	snapshot.Progress = p.progress(steps, total)
 
 	// compute top-level aggregates
 	{
-		snapshot.Doing = strings.Join(doing, ", ")
+		snapshot.Doing = p.doingFormat.format(doing)
 		var (
-			isDone       = snapshot.Completed > 0 && snapshot.InProgress == 0 && snapshot.NotStarted == 0
-			isInProgress = snapshot.Completed < snapshot.Total && snapshot.InProgress > 0
-			isNotStarted = snapshot.Completed == 0 && snapshot.InProgress == 0
-			isStopped    = snapshot.Completed > 0 && snapshot.InProgress == 0 && snapshot.NotStarted > 0
+			pending      = snapshot.NotStarted + snapshot.Interrupted
+			finished     = snapshot.Completed + snapshot.Skipped
+			terminated   = finished + snapshot.Failed + snapshot.Canceled
+			isFailed     = snapshot.Failed > 0 && snapshot.InProgress == 0 && pending == 0
+			isCanceled   = snapshot.Canceled > 0 && snapshot.InProgress == 0 && pending == 0
+			isDone       = finished > 0 && snapshot.InProgress == 0 && pending == 0
+			isInProgress = finished < snapshot.Total && snapshot.InProgress > 0
+			isNotStarted = terminated == 0 && snapshot.InProgress == 0
+			isStopped    = terminated > 0 && snapshot.InProgress == 0 && pending > 0
 		)
 		switch {
+		case isFailed:
+			snapshot.State = StateFailed
+			if snapshot.StartedAt != nil {
+				snapshot.TotalDuration = snapshot.DoneAt.Sub(*snapshot.StartedAt)
+			}
+		case isCanceled:
+			snapshot.State = StateCanceled
+			if snapshot.StartedAt != nil {
+				snapshot.TotalDuration = snapshot.DoneAt.Sub(*snapshot.StartedAt)
+			}
 		case isDone:
 			snapshot.State = StateDone
-			if snapshot.Completed != snapshot.Total {
+			if finished != snapshot.Total {
 				panic(fmt.Sprintf("snapshot has a strange state: %s", u.JSON(snapshot)))
 			}
 			snapshot.Progress = 1 // avoid having 0.99999999999 by adding floats together
-			snapshot.TotalDuration = snapshot.DoneAt.Sub(*snapshot.StartedAt)
+			if snapshot.StartedAt != nil {
+				snapshot.TotalDuration = snapshot.DoneAt.Sub(*snapshot.StartedAt)
+			}
 		case isInProgress:
 			snapshot.State = StateInProgress
 			snapshot.DoneAt = nil
-			snapshot.TotalDuration = time.Since(*snapshot.StartedAt)
+			snapshot.TotalDuration = p.since(*snapshot.StartedAt)
+			if snapshot.Progress > 0 {
+				// linear extrapolation based on the elapsed time
+				elapsed := float64(snapshot.TotalDuration)
+				snapshot.CompletionEstimate = time.Duration(elapsed/snapshot.Progress - elapsed)
+			}
 		case isNotStarted:
 			snapshot.State = StateNotStarted
 			snapshot.DoneAt = nil
 		case isStopped:
 			snapshot.State = StateStopped
 			snapshot.DoneAt = nil
-			snapshot.TotalDuration = time.Since(*snapshot.StartedAt)
+			if snapshot.StartedAt != nil {
+				snapshot.TotalDuration = p.since(*snapshot.StartedAt)
+			}
 		default:
 			panic(fmt.Sprintf("snapshot has a strange state: %s", u.JSON(snapshot)))
 		}
 	}
 
+	snapshot.Outcome = snapshot.outcome()
+
+	if p.indeterminate && !snapshot.State.IsTerminal() {
+		snapshot.Indeterminate = true
+		snapshot.Progress = 0
+		snapshot.CompletionEstimate = 0
+	}
+
 	return snapshot
 }
 
@@ -258,30 +525,94 @@ func (p *Progress) Snapshot() Snapshot {
 func (p *Progress) MarshalJSON() ([]byte, error) {
 	type alias Progress
 	type enriched struct {
+		Version int `json:"version"`
 		*alias
 		Snapshot Snapshot `json:"snapshot"`
+		Events   []Event  `json:"events,omitempty"`
 	}
 	return json.Marshal(&enriched{
+		Version:  SchemaVersion,
 		alias:    (*alias)(p),
 		Snapshot: p.Snapshot(),
+		Events:   p.Events(),
 	})
 }
 
+// UnmarshalJSON is a custom JSON unmarshaler that restores the steps and the event log.
+// Documents written with an older SchemaVersion are migrated first.
+// The snapshot is ignored, it is computed again on demand.
+func (p *Progress) UnmarshalJSON(data []byte) error {
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	type alias Progress
+	type enriched struct {
+		Version int `json:"version"`
+		*alias
+		Events []Event `json:"events,omitempty"`
+	}
+	decoded := enriched{alias: (*alias)(p)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	return p.restore(decoded.Version, decoded.Events)
+}
+
+// restore migrates the decoded document of 'version', then validates the decoded steps, attaches them to
+// the Progress and restores the event log.
+// The caller is responsible for holding the main lock.
+func (p *Progress) restore(version int, events []Event) error {
+	p.events = events
+	if err := p.migrate(version); err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(p.Steps))
+	for _, step := range p.Steps {
+		if step.ID == "" {
+			return ErrStepRequiresID
+		}
+		if seen[step.ID] {
+			return ErrStepIDShouldBeUnique
+		}
+		seen[step.ID] = true
+		if step.State == "" {
+			step.State = StateNotStarted
+		}
+		step.parent = p
+		if err := step.unmarshalData(); err != nil {
+			return err
+		}
+	}
+	p.trimEvents()
+	return nil
+}
+
 // Progress returns the current completion rate, it's a faster alternative to Progress.Snapshot().Progress.
 // The returned value is between 0.0 and 1.0.
 func (p *Progress) Progress() float64 {
-	total := len(p.Steps)
+	if p.indeterminate && !p.isTerminal() {
+		return 0
+	}
+	return p.progress(p.Steps, p.total())
+}
+
+// progress computes the completion rate of 'steps', out of 'total' steps.
+func (p *Progress) progress(steps []*Step, total int) float64 {
 	progress := notStartedProgress
-	for _, step := range p.Steps {
+	for _, step := range steps {
 		switch step.State {
 		case StateNotStarted:
 			// noop
 		case StateInProgress:
 			// in-progress task count as partially done
-			progress += (step.Progress / float64(total))
+			if p.percentStrategy == PercentSteps {
+				progress += (step.Progress / float64(total))
+			}
 			// FIXME: support per-task progress
-		case StateDone:
+		case StateDone, StateSkipped:
 			progress += (doneProgress / float64(total))
+		case StateCanceled, StateInterrupted, StateFailed:
+			// noop
 		case StateStopped:
 			panic(fmt.Sprintf("step cannot be in stopped state (yet!): %s", u.JSON(step)))
 		default:
@@ -291,12 +622,19 @@ func (p *Progress) Progress() float64 {
 	return progress
 }
 
-func (p *Progress) isDone() bool {
-	if len(p.Steps) == 0 {
+// invalidTransition panics with 'msg', unless the Progress is in safe mode, see WithSafeMode.
+func (p *Progress) invalidTransition(msg string) {
+	if !p.safeMode {
+		panic(msg)
+	}
+}
+
+func (p *Progress) isTerminal() bool {
+	if len(p.Steps) == 0 || len(p.Steps) < p.expectedTotal {
 		return false
 	}
 	for _, step := range p.Steps {
-		if step.State != StateDone {
+		if !step.State.IsTerminal() {
 			return false
 		}
 	}
@@ -306,15 +644,37 @@ func (p *Progress) isDone() bool {
 // Step represents a progress step.
 // It always have an 'id' and can be customized using helpers.
 type Step struct {
-	ID          string      `json:"id,omitempty"`
-	Description string      `json:"description,omitempty"`
-	StartedAt   *time.Time  `json:"started_at,omitempty"`
-	DoneAt      *time.Time  `json:"done_at,omitempty"`
-	State       State       `json:"state,omitempty"`
-	Data        interface{} `json:"data,omitempty"`
-	Progress    float64     `json:"progress,omitempty"`
+	ID          string            `json:"id,omitempty" yaml:"id,omitempty"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+	StartedAt   *time.Time        `json:"started_at,omitempty" yaml:"started_at,omitempty"`
+	DoneAt      *time.Time        `json:"done_at,omitempty" yaml:"done_at,omitempty"`
+	State       State             `json:"state,omitempty" yaml:"state,omitempty"`
+	Data        interface{}       `json:"data,omitempty" yaml:"data,omitempty"`
+	Progress    float64           `json:"progress,omitempty" yaml:"progress,omitempty"`
+	Actor       string            `json:"actor,omitempty" yaml:"actor,omitempty"`
+	Group       string            `json:"group,omitempty" yaml:"group,omitempty"`
+	Tags        []string          `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Error       string            `json:"error,omitempty" yaml:"error,omitempty"`
+	SkipReason  string            `json:"skip_reason,omitempty" yaml:"skip_reason,omitempty"`
+	Units       int64             `json:"units,omitempty" yaml:"units,omitempty"`
+	TotalUnits  int64             `json:"total_units,omitempty" yaml:"total_units,omitempty"`
+	Attempts    []Attempt         `json:"attempts,omitempty" yaml:"attempts,omitempty"`
+	Logs        []LogLine         `json:"logs,omitempty" yaml:"logs,omitempty"`
 
-	parent *Progress
+	parent         *Progress
+	child          *Progress
+	err            error
+	fn             StepFunc
+	dependencies   []string
+	retry          *RetryPolicy
+	skipIf         func() bool
+	skipReason     string
+	priority       int
+	doneCh         chan struct{}
+	doneChClosed   bool
+	rawData        json.RawMessage
+	staleThreshold time.Duration
 }
 
 // SetProgress sets the current step progress rate.
@@ -327,13 +687,16 @@ func (s *Step) SetProgress(progress float64) *Step {
 
 	s.parent.mainMutex.Lock()
 	defer s.parent.mainMutex.Unlock()
+	if !s.canTransition("SetProgress", StateNotStarted, StateInProgress, StateInterrupted) {
+		return s
+	}
 	s.Progress = progress
+	now := s.parent.now()
 	if progress == notStartedProgress {
-		s.State = StateNotStarted
+		s.setState(StateNotStarted, now)
 	} else {
-		s.State = StateInProgress
+		s.setState(StateInProgress, now)
 		if s.StartedAt == nil {
-			now := time.Now()
 			s.StartedAt = &now
 		}
 	}
@@ -344,6 +707,8 @@ func (s *Step) SetProgress(progress float64) *Step {
 // SetDescription sets a custom step description.
 // It returns itself (*Step) for chaining.
 func (s *Step) SetDescription(desc string) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
 	s.Description = desc
 	s.parent.publishStep(s)
 	return s
@@ -352,50 +717,147 @@ func (s *Step) SetDescription(desc string) *Step {
 // SetData sets a custom step data.
 // It returns itself (*Step) for chaining.
 func (s *Step) SetData(data interface{}) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
 	s.Data = data
 	s.parent.publishStep(s)
 	return s
 }
 
+// SetActor sets the name of the component working on the step.
+// It is recorded in the event log for each subsequent transition.
+// It returns itself (*Step) for chaining.
+func (s *Step) SetActor(actor string) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	s.Actor = actor
+	s.parent.publishStep(s)
+	return s
+}
+
+// SetTotalUnits sets the expected number of units (i.e., bytes) of the step.
+// When positive, the step progress rate is computed from the processed units.
+// It returns itself (*Step) for chaining.
+func (s *Step) SetTotalUnits(total int64) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	s.TotalUnits = total
+	s.updateUnitsProgress()
+	s.parent.publishStep(s)
+	return s
+}
+
+// SetUnits sets the number of processed units of the step.
+// A not started step is automatically started.
+// It returns itself (*Step) for chaining.
+func (s *Step) SetUnits(units int64) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	s.setUnits(units)
+	return s
+}
+
+// AddUnits adds 'n' to the number of processed units of the step.
+// A not started step is automatically started.
+// It returns itself (*Step) for chaining.
+func (s *Step) AddUnits(n int64) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	s.setUnits(s.Units + n)
+	return s
+}
+
+// setUnits updates the processed units and publishes the change.
+// The caller is responsible for holding the main lock.
+func (s *Step) setUnits(units int64) {
+	s.Units = units
+	if s.State == StateNotStarted {
+		now := s.parent.now()
+		s.setState(StateInProgress, now)
+		s.StartedAt = &now
+		s.Progress = defaultStartProgress
+	}
+	s.updateUnitsProgress()
+	s.parent.publishStep(s)
+}
+
+// updateUnitsProgress computes the progress rate of an in-progress step from its units.
+func (s *Step) updateUnitsProgress() {
+	if s.TotalUnits <= 0 || s.State != StateInProgress {
+		return
+	}
+	ratio := float64(s.Units) / float64(s.TotalUnits)
+	if ratio > 1 {
+		ratio = 1
+	}
+	s.Progress = ratio
+}
+
+// SetGroup sets the group of the step, i.e., a phase or a kind of work like "download".
+// It returns itself (*Step) for chaining.
+func (s *Step) SetGroup(group string) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	s.Group = group
+	s.parent.publishStep(s)
+	return s
+}
+
 // Start marks a step as started.
 // If a step was already InProgress or Done, it panics.
 func (s *Step) Start() *Step {
 	s.parent.mainMutex.Lock()
 	defer s.parent.mainMutex.Unlock()
+	if !s.canTransition("Start", StateNotStarted, StateInterrupted) {
+		return s
+	}
 	if s.State == StateInProgress {
-		panic("cannot Step.Start() an already in-progress step.")
+		s.parent.invalidTransition("cannot Step.Start() an already in-progress step.")
+		return s
 	}
 	if s.State == StateDone {
-		panic("cannot Step.Start() an already done step.")
+		s.parent.invalidTransition("cannot Step.Start() an already done step.")
+		return s
 	}
-	s.State = StateInProgress
-	now := time.Now()
+	s.start(s.parent.now())
+	return s
+}
+
+// start marks the step as started and publishes the change.
+// The caller is responsible for holding the main lock.
+func (s *Step) start(now time.Time) {
+	s.setState(StateInProgress, now)
 	s.StartedAt = &now
 	s.Progress = defaultStartProgress
+	s.updateUnitsProgress()
 	s.parent.publishStep(s)
-	return s
 }
 
 // SetAsCurrent stops all in-progress steps and start this one.
 func (s *Step) SetAsCurrent() *Step {
 	s.parent.mainMutex.Lock()
 	defer s.parent.mainMutex.Unlock()
+	if !s.canTransition("SetAsCurrent", StateNotStarted, StateInterrupted) {
+		return s
+	}
 	if s.State == StateInProgress {
-		panic("cannot Step.Start() an already in-progress step.")
+		s.parent.invalidTransition("cannot Step.Start() an already in-progress step.")
+		return s
 	}
 	if s.State == StateDone {
-		panic("cannot Step.Start() an already done step.")
+		s.parent.invalidTransition("cannot Step.Start() an already done step.")
+		return s
 	}
-	now := time.Now()
+	now := s.parent.now()
 	for _, step := range s.parent.Steps {
 		if step.State == StateInProgress {
-			step.State = StateDone
+			step.setState(StateDone, now)
 			step.DoneAt = &now
 			s.parent.publishStep(step)
 		}
 	}
 	s.Progress = defaultStartProgress
-	s.State = StateInProgress
+	s.setState(StateInProgress, now)
 	s.StartedAt = &now
 	s.parent.publishStep(s)
 	return s
@@ -403,47 +865,179 @@ func (s *Step) SetAsCurrent() *Step {
 
 // Done marks a step as done.
 // If the step was already done, it panics.
+// With WithAutoAdvance, the next step is started.
 func (s *Step) Done() *Step {
 	s.parent.mainMutex.Lock()
 	defer s.parent.mainMutex.Unlock()
+	if !s.canTransition("Done", StateNotStarted, StateInProgress, StateInterrupted) {
+		return s
+	}
 	if s.State == StateDone {
-		panic("cannot Step.Done() an already done step.")
+		s.parent.invalidTransition("cannot Step.Done() an already done step.")
+		return s
+	}
+	now := s.parent.now()
+	s.done(now)
+	s.parent.advance(s, now)
+	if s.parent.isTerminal() {
+		s.parent.terminate()
 	}
-	s.State = StateDone
-	now := time.Now()
+	return s
+}
+
+// done marks the step as done and publishes the change.
+// The caller is responsible for holding the main lock.
+func (s *Step) done(now time.Time) {
+	s.setState(StateDone, now)
 	if s.StartedAt == nil {
 		s.StartedAt = &now
 	}
 	s.DoneAt = &now
 	s.parent.publishStep(s)
-	if s.parent.isDone() {
-		s.parent.closeSubscribers()
+}
+
+// Cancel marks a step as canceled.
+// If the step was already done or canceled, it panics.
+func (s *Step) Cancel() *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	if !s.canTransition("Cancel", StateNotStarted, StateInProgress, StateInterrupted) {
+		return s
+	}
+	if s.State.IsTerminal() {
+		s.parent.invalidTransition("cannot Step.Cancel() an already terminated step.")
+		return s
+	}
+	s.cancel(s.parent.now())
+	if s.parent.isTerminal() {
+		s.parent.terminate()
+	}
+	return s
+}
+
+// Fail marks a step as failed, recording the message of 'err' in Step.Error.
+// If the step was already done, canceled, or failed, it panics.
+func (s *Step) Fail(err error) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	if !s.canTransition("Fail", StateNotStarted, StateInProgress, StateInterrupted) {
+		return s
+	}
+	if s.State.IsTerminal() {
+		s.parent.invalidTransition("cannot Step.Fail() an already terminated step.")
+		return s
+	}
+	s.fail(err, s.parent.now())
+	if s.parent.isTerminal() {
+		s.parent.terminate()
 	}
 	return s
 }
 
+// Skip marks a step as skipped, recording 'reason' in Step.SkipReason.
+// A skipped step counts as completed in the progress rate and satisfies the dependencies of Progress.Run.
+// If the step was already started or terminated, it panics.
+func (s *Step) Skip(reason string) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	if !s.canTransition("Skip", StateNotStarted, StateInterrupted) {
+		return s
+	}
+	if s.State == StateInProgress || s.State.IsTerminal() {
+		s.parent.invalidTransition("cannot Step.Skip() an already started step.")
+		return s
+	}
+	s.skip(reason, s.parent.now())
+	if s.parent.isTerminal() {
+		s.parent.terminate()
+	}
+	return s
+}
+
+// skip marks the step as skipped and publishes the change.
+// The caller is responsible for holding the main lock.
+func (s *Step) skip(reason string, now time.Time) {
+	s.SkipReason = reason
+	s.setState(StateSkipped, now)
+	s.DoneAt = &now
+	s.parent.publishStep(s)
+}
+
+// fail marks the step as failed and publishes the change.
+// The caller is responsible for holding the main lock.
+func (s *Step) fail(err error, now time.Time) {
+	if err != nil {
+		s.setError(err)
+	}
+	s.setState(StateFailed, now)
+	s.DoneAt = &now
+	s.parent.publishStep(s)
+}
+
+// cancel marks the step as canceled and publishes the change.
+// The caller is responsible for holding the main lock.
+func (s *Step) cancel(now time.Time) {
+	s.setState(StateCanceled, now)
+	s.DoneAt = &now
+	s.parent.publishStep(s)
+}
+
 // MarshalJSON is a custom JSON marshaler that automatically computes and append some runtime metadata.
 func (s *Step) MarshalJSON() ([]byte, error) {
 	type alias Step
 	type enriched struct {
 		alias
-		Duration time.Duration `json:"duration,omitempty"`
+		Data          interface{} `json:"data,omitempty"`
+		Duration      interface{} `json:"duration,omitempty"`
+		DurationHuman string      `json:"duration_human,omitempty"`
+		Stale         bool        `json:"stale,omitempty"`
+	}
+	data, err := s.marshalData()
+	if err != nil {
+		return nil, err
 	}
 	return json.Marshal(&enriched{
-		alias:    (alias)(*s),
-		Duration: s.Duration(),
+		alias:         (alias)(*s),
+		Data:          data,
+		Duration:      s.durationFormat().encode(s.Duration()),
+		DurationHuman: s.humanDuration(s.Duration()),
+		Stale:         s.isStale(),
 	})
 }
 
+// UnmarshalJSON is a custom JSON unmarshaler that ignores the runtime metadata added by MarshalJSON.
+// The decoded step is only usable with the helpers once attached to a Progress, i.e., by Progress.UnmarshalJSON.
+func (s *Step) UnmarshalJSON(data []byte) error {
+	type alias Step
+	type enriched struct {
+		*alias
+		Data json.RawMessage `json:"data,omitempty"`
+	}
+	decoded := enriched{alias: (*alias)(s)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	s.Data = nil
+	s.rawData = decoded.Data
+	if len(decoded.Data) > 0 {
+		return json.Unmarshal(decoded.Data, &s.Data)
+	}
+	return nil
+}
+
 // Duration computes the step duration.
 func (s *Step) Duration() time.Duration {
 	var ret time.Duration
 	switch s.State {
 	case StateInProgress:
-		ret = time.Since(*s.StartedAt)
+		ret = s.parent.since(*s.StartedAt)
 	case StateDone:
 		ret = s.DoneAt.Sub(*s.StartedAt)
-	case StateNotStarted:
+	case StateCanceled, StateFailed:
+		if s.StartedAt != nil {
+			ret = s.DoneAt.Sub(*s.StartedAt)
+		}
+	case StateNotStarted, StateInterrupted, StateSkipped:
 		// noop
 	case StateStopped:
 		panic(fmt.Sprintf("step cannot be in stopped state (yet!): %s", u.JSON(s)))
@@ -463,4 +1057,7 @@ func (s *Step) title() string {
 var (
 	ErrStepRequiresID       = errors.New("progress.AddStep requires a non-empty ID as argument")
 	ErrStepIDShouldBeUnique = errors.New("progress.AddStep requires a unique ID as argument")
+	ErrStepNotFound         = errors.New("progress: no step matches the provided ID")
+	ErrUnknownDataType      = errors.New("progress: unknown data type")
+	ErrDependencyCycle      = errors.New("progress: cyclic step dependencies")
 )
@@ -7,12 +7,14 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"moul.io/progress"
+	"moul.io/progress/progresstest"
 	"moul.io/u"
 )
 
 func TestFlow(t *testing.T) {
 	// initialize a new progress
-	prog := progress.New()
+	clock := progresstest.NewClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	prog := progress.New(progress.WithClock(clock))
 	{
 		require.NotEmpty(t, prog)
 		require.Empty(t, prog.Steps)
@@ -94,7 +96,7 @@ func TestFlow(t *testing.T) {
 
 	// mark the first step as done
 	{
-		time.Sleep(200 * time.Millisecond)
+		clock.Advance(200 * time.Millisecond)
 		step1 := prog.Get("step1")
 		step1.Done()
 		require.Equal(t, progress.StateDone, step1.State)
@@ -189,7 +191,7 @@ func TestFlow(t *testing.T) {
 
 	// mark step3 and step4 as done at the same time
 	{
-		time.Sleep(200 * time.Millisecond)
+		clock.Advance(200 * time.Millisecond)
 		step1 := prog.Get("step1")
 		step2 := prog.Get("step2")
 		step3 := prog.Get("step3")
@@ -207,11 +209,11 @@ func TestFlow(t *testing.T) {
 		require.Equal(t, float64(1), snapshot.Progress)
 		require.Equal(t, snapshot.Progress, prog.Progress())
 
-		require.True(t, step1.Duration() > 200*time.Millisecond && step1.Duration() < 400*time.Millisecond)
+		require.Equal(t, 200*time.Millisecond, step1.Duration())
 		require.Zero(t, step2.Duration())
-		require.True(t, step3.Duration() > 200*time.Millisecond && step3.Duration() < 400*time.Millisecond)
-		require.True(t, step4.Duration() > 200*time.Millisecond && step4.Duration() < 400*time.Millisecond)
-		require.True(t, snapshot.TotalDuration > 400*time.Millisecond && snapshot.TotalDuration < 600*time.Millisecond)
+		require.Equal(t, 200*time.Millisecond, step3.Duration())
+		require.Equal(t, 200*time.Millisecond, step4.Duration())
+		require.Equal(t, 400*time.Millisecond, snapshot.TotalDuration)
 	}
 
 	// create a new step and use SetProgress instead of Start
@@ -418,3 +420,192 @@ func TestSubcribe_closeReopen(t *testing.T) {
 	require.Nil(t, <-ch2)
 	require.Nil(t, <-ch1)
 }
+
+func TestSubscribe_filters(t *testing.T) {
+	prog := progress.New()
+	defer prog.Close()
+	all := prog.Subscribe()
+	onlyDone := prog.Subscribe(progress.FilterStates(progress.StateDone))
+	onlyStep2 := prog.Subscribe(func(step *progress.Step) bool { return step.ID == "step2" })
+
+	prog.AddStep("step1")
+	prog.AddStep("step2")
+	prog.Get("step1").Start()
+	prog.Get("step2").Start()
+	prog.Get("step1").Done()
+	prog.Get("step2").Done()
+
+	collect := func(ch chan *progress.Step) []string {
+		ret := []string{}
+		for step := range ch {
+			ret = append(ret, fmt.Sprintf("%s:%s", step.ID, step.State))
+		}
+		return ret
+	}
+	require.Len(t, collect(all), 6)
+	require.Equal(t, []string{"step1:done", "step2:done"}, collect(onlyDone))
+	require.Equal(t, []string{"step2:not started", "step2:in progress", "step2:done"}, collect(onlyStep2))
+}
+
+func TestUnsubscribe(t *testing.T) {
+	prog := progress.New()
+	defer prog.Close()
+	ch1 := prog.Subscribe()
+	ch2 := prog.Subscribe()
+
+	prog.AddStep("step1")
+	require.NotNil(t, <-ch1)
+	require.NotNil(t, <-ch2)
+
+	prog.Unsubscribe(ch1)
+	prog.Unsubscribe(ch1) // noop
+	_, ok := <-ch1
+	require.False(t, ok)
+
+	prog.Get("step1").Start()
+	require.NotNil(t, <-ch2)
+}
+
+func TestStepUnits(t *testing.T) {
+	prog := progress.New()
+	step := prog.AddStep("step1").SetTotalUnits(200)
+	require.Equal(t, progress.StateNotStarted, step.State)
+
+	step.AddUnits(50)
+	require.Equal(t, progress.StateInProgress, step.State)
+	require.NotNil(t, step.StartedAt)
+	require.Equal(t, int64(50), step.Units)
+	require.Equal(t, 0.25, step.Progress)
+
+	step.SetUnits(150)
+	require.Equal(t, 0.75, step.Progress)
+	require.Equal(t, 0.75, prog.Progress())
+
+	step.AddUnits(100)
+	require.Equal(t, int64(250), step.Units)
+	require.Equal(t, 1.0, step.Progress)
+	require.Equal(t, progress.StateInProgress, step.State)
+
+	step.Done()
+	require.Equal(t, progress.StateDone, prog.Snapshot().State)
+}
+
+func TestStepFail(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Start().Fail(fmt.Errorf("disk full"))
+	step := prog.Get("step1")
+	require.Equal(t, progress.StateFailed, step.State)
+	require.Equal(t, "disk full", step.Error)
+	require.NotNil(t, step.DoneAt)
+	require.True(t, step.State.IsTerminal())
+	require.Panics(t, func() { step.Fail(nil) })
+	require.Panics(t, func() { step.Cancel() })
+
+	snapshot := prog.Snapshot()
+	require.Equal(t, progress.StateFailed, snapshot.State)
+	require.Equal(t, 1, snapshot.Failed)
+	require.Equal(t, float64(0), snapshot.Progress)
+}
+
+func TestStepFail_pending(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Start().Fail(fmt.Errorf("disk full"))
+	prog.AddStep("step2")
+	prog.AddStep("step3").Start().Cancel()
+	prog.AddStep("step4").Start()
+
+	// a failed step does not make the progress terminal while other steps are in progress or pending
+	snapshot := prog.Snapshot()
+	require.Equal(t, progress.StateInProgress, snapshot.State)
+	prog.Get("step4").Done()
+	snapshot = prog.Snapshot()
+	require.Equal(t, progress.StateStopped, snapshot.State)
+	require.False(t, snapshot.State.IsTerminal())
+	require.Equal(t, progress.OutcomeNone, snapshot.Outcome)
+	require.Nil(t, snapshot.DoneAt)
+	select {
+	case <-prog.DoneCh():
+		t.Fatal("the progress should not be done")
+	default:
+	}
+
+	prog.Get("step2").Start().Done()
+	snapshot = prog.Snapshot()
+	require.Equal(t, progress.StateFailed, snapshot.State)
+	require.Equal(t, progress.OutcomePartial, snapshot.Outcome)
+	<-prog.DoneCh()
+}
+
+func TestStepSkip(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Skip("not needed")
+	prog.AddStep("step2")
+	step := prog.Get("step1")
+	require.Equal(t, progress.StateSkipped, step.State)
+	require.Equal(t, "not needed", step.SkipReason)
+	require.Zero(t, step.Duration())
+	require.Panics(t, func() { step.Skip("again") })
+	require.Panics(t, func() { prog.Get("step2").Start().Skip("too late") })
+
+	snapshot := prog.Snapshot()
+	require.Equal(t, progress.StateInProgress, snapshot.State)
+	require.Equal(t, 1, snapshot.Skipped)
+	require.Equal(t, 0.75, snapshot.Progress)
+
+	// a progress made only of skipped steps is done
+	prog = progress.New()
+	prog.AddStep("step1").Skip("")
+	snapshot = prog.Snapshot()
+	require.Equal(t, progress.StateDone, snapshot.State)
+	require.Zero(t, snapshot.TotalDuration)
+	select {
+	case <-prog.DoneCh():
+	default:
+		t.Fatal("progress should be terminal")
+	}
+}
+
+func TestGetE(t *testing.T) {
+	prog := progress.New()
+	step := prog.AddStep("a")
+
+	got, err := prog.GetE("a")
+	require.NoError(t, err)
+	require.Equal(t, step, got)
+	require.Equal(t, step, prog.MustGet("a"))
+
+	got, err = prog.GetE("b")
+	require.Nil(t, got)
+	require.ErrorIs(t, err, progress.ErrStepNotFound)
+	require.EqualError(t, err, `progress: no step matches the provided ID: "b"`)
+	require.PanicsWithError(t, err.Error(), func() { prog.MustGet("b") })
+
+	_, err = prog.GetE("")
+	require.Equal(t, progress.ErrStepRequiresID, err)
+}
+
+func TestAddSteps(t *testing.T) {
+	prog := progress.New()
+	steps := prog.AddSteps("a", "b", "c")
+	require.Len(t, steps, 3)
+	require.Equal(t, "b", steps[1].ID)
+	require.Equal(t, steps, prog.Steps)
+	require.Panics(t, func() { prog.AddSteps("d", "a") })
+	require.Panics(t, func() { prog.AddSteps("d", "") })
+	require.Len(t, prog.Steps, 3)
+
+	steps, err := prog.AddStepsFrom([]progress.StepDef{
+		{ID: "d", Description: "hello"},
+		{ID: "e", Data: 42},
+	})
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+	require.Equal(t, "hello", prog.MustGet("d").Description)
+	require.Equal(t, 42, prog.MustGet("e").Data)
+
+	steps, err = prog.AddStepsFrom([]progress.StepDef{{ID: "f"}, {ID: "f"}})
+	require.Nil(t, steps)
+	require.Equal(t, progress.ErrStepIDShouldBeUnique, err)
+	require.Len(t, prog.Steps, 5)
+	require.Len(t, prog.Events(), 5)
+}
@@ -0,0 +1,152 @@
+// Package progresscli wires a Progress to a command-line tool: renderer selection, interrupt handling,
+// and final summary.
+//
+// The Mode type implements flag.Value and pflag.Value, so it can be registered as a flag with the standard
+// library, cobra, or urfave/cli:
+//
+//	var mode progresscli.Mode
+//	flag.Var(&mode, "progress", progresscli.FlagUsage)
+//	flag.Parse()
+//
+//	ctx, finish := progresscli.Attach(context.Background(), prog, progresscli.Options{Mode: mode})
+//	err := prog.Run(ctx)
+//	if finishErr := finish(); err == nil {
+//		err = finishErr
+//	}
+package progresscli // import "moul.io/progress/progresscli"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"golang.org/x/term"
+	"moul.io/progress"
+	"moul.io/progress/render"
+)
+
+// Mode selects how a Progress is displayed.
+type Mode string
+
+const (
+	// ModeAuto uses ModeBar on terminals and ModePlain otherwise.
+	ModeAuto Mode = "auto"
+	// ModeBar draws a progress bar redrawn in place, see render.Bar.
+	ModeBar Mode = "bar"
+	// ModePlain prints a line per transition, see render.Plain.
+	ModePlain Mode = "plain"
+	// ModeJSON prints a JSON event per transition, see progress.StreamEvents, and the summary as JSON.
+	ModeJSON Mode = "json"
+	// ModeQuiet prints nothing.
+	ModeQuiet Mode = "quiet"
+)
+
+// FlagUsage is a usage string for a flag configuring a Mode.
+const FlagUsage = "progress output: auto, bar, plain, json, or quiet"
+
+// ErrInvalidMode is returned when parsing an unknown Mode.
+var ErrInvalidMode = errors.New("progresscli: invalid progress mode")
+
+// String implements flag.Value.
+func (m *Mode) String() string {
+	if *m == "" {
+		return string(ModeAuto)
+	}
+	return string(*m)
+}
+
+// Set implements flag.Value.
+func (m *Mode) Set(value string) error {
+	switch mode := Mode(value); mode {
+	case ModeAuto, ModeBar, ModePlain, ModeJSON, ModeQuiet:
+		*m = mode
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidMode, value)
+	}
+}
+
+// Type implements pflag.Value.
+func (m *Mode) Type() string {
+	return "mode"
+}
+
+// Options configures Attach.
+type Options struct {
+	// Mode selects the output, ModeAuto if empty.
+	Mode Mode
+	// Output is the writer of the progress and of the summary, os.Stderr if nil.
+	Output io.Writer
+	// NoSummary disables the summary printed when finishing.
+	NoSummary bool
+}
+
+// Attach displays 'prog' according to 'opts' until the returned finish function is called.
+//
+// The returned context is canceled on interrupt (SIGINT), and every unfinished step is then canceled
+// so the final state is accurate; it should be passed to the code updating the Progress.
+// The finish function stops the display, prints the summary, and returns the first rendering error.
+func Attach(ctx context.Context, prog *progress.Progress, opts Options) (context.Context, func() error) {
+	w := opts.Output
+	if w == nil {
+		w = os.Stderr
+	}
+	mode := opts.Mode
+	if mode == "" || mode == ModeAuto {
+		mode = ModePlain
+		if isTerminal(w) {
+			mode = ModeBar
+		}
+	}
+
+	ctx, stopSignals := signal.NotifyContext(ctx, os.Interrupt)
+	unbind := prog.BindContext(ctx)
+
+	var stop func() error
+	switch mode {
+	case ModeBar, ModePlain:
+		var renderer render.Renderer = render.NewPlain(w)
+		if mode == ModeBar {
+			renderer = render.NewBar(w)
+		}
+		renderCtx, cancelRender := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- render.Run(renderCtx, renderer, prog, render.DefaultInterval(w)) }()
+		stop = func() error {
+			cancelRender()
+			if err := <-done; err != nil && !errors.Is(err, context.Canceled) {
+				return err
+			}
+			return nil
+		}
+	case ModeJSON:
+		stop = prog.StreamEvents(w)
+	default:
+		stop = func() error { return nil }
+	}
+
+	return ctx, func() error {
+		defer stopSignals()
+		defer unbind()
+		err := stop()
+		if opts.NoSummary || err != nil {
+			return err
+		}
+		switch mode {
+		case ModeBar, ModePlain:
+			return render.PrintSummary(w, prog.Summary())
+		case ModeJSON:
+			return json.NewEncoder(w).Encode(prog.Summary())
+		}
+		return nil
+	}
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
@@ -0,0 +1,80 @@
+package progresscli_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progresscli"
+)
+
+func TestMode(t *testing.T) {
+	var mode progresscli.Mode
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&mode, "progress", progresscli.FlagUsage)
+	require.Equal(t, "auto", mode.String())
+
+	require.NoError(t, fs.Parse([]string{"--progress=json"}))
+	require.Equal(t, progresscli.ModeJSON, mode)
+
+	err := mode.Set("fancy")
+	require.ErrorIs(t, err, progresscli.ErrInvalidMode)
+	require.Equal(t, progresscli.ModeJSON, mode)
+}
+
+func run(t *testing.T, mode progresscli.Mode) string {
+	t.Helper()
+	var buf bytes.Buffer
+	prog := progress.New()
+	prog.AddStep("step1").SetFunc(func(context.Context, *progress.Step) error { return nil })
+	prog.AddStep("step2").SetFunc(func(context.Context, *progress.Step) error { return nil })
+
+	ctx, finish := progresscli.Attach(context.Background(), prog, progresscli.Options{Mode: mode, Output: &buf})
+	require.NoError(t, prog.Run(ctx))
+	require.NoError(t, finish())
+	return buf.String()
+}
+
+func TestAttach(t *testing.T) {
+	// not a terminal
+	output := run(t, progresscli.ModeAuto)
+	require.Contains(t, output, "step1 started")
+	require.Contains(t, output, "✓ done: 2/2 steps")
+	require.NotContains(t, output, "\x1b[")
+
+	output = run(t, progresscli.ModeBar)
+	require.Contains(t, output, "100%")
+	require.Contains(t, output, "✓ done: 2/2 steps")
+
+	output = run(t, progresscli.ModeJSON)
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	var summary progress.Summary
+	require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &summary))
+	require.Equal(t, progress.StateDone, summary.State)
+	var event progress.Event
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &event))
+	require.Equal(t, "step1", event.StepID)
+
+	require.Empty(t, run(t, progresscli.ModeQuiet))
+}
+
+func TestAttach_canceled(t *testing.T) {
+	var buf bytes.Buffer
+	prog := progress.New()
+	prog.AddStep("step1").Start()
+	prog.AddStep("step2")
+
+	parent, cancel := context.WithCancel(context.Background())
+	ctx, finish := progresscli.Attach(parent, prog, progresscli.Options{Mode: progresscli.ModePlain, Output: &buf})
+	cancel()
+	<-ctx.Done()
+	require.NoError(t, prog.Wait(context.Background()))
+	require.NoError(t, finish())
+	require.Equal(t, progress.StateCanceled, prog.Snapshot().State)
+	require.Contains(t, buf.String(), "canceled: step1, step2")
+}
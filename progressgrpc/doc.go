@@ -0,0 +1,4 @@
+// Package progressgrpc provides a gRPC service exposing a Progress, built on top of the progresspb messages.
+package progressgrpc // import "moul.io/progress/progressgrpc"
+
+//go:generate protoc -I . -I ../progresspb --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative progress_service.proto
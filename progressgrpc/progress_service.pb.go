@@ -0,0 +1,308 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        v5.28.3
+// source: progress_service.proto
+
+package progressgrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	progresspb "moul.io/progress/progresspb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSnapshotRequest) Reset() {
+	*x = GetSnapshotRequest{}
+	mi := &file_progress_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSnapshotRequest) ProtoMessage() {}
+
+func (x *GetSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*GetSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_progress_service_proto_rawDescGZIP(), []int{0}
+}
+
+type ListStepsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStepsRequest) Reset() {
+	*x = ListStepsRequest{}
+	mi := &file_progress_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStepsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStepsRequest) ProtoMessage() {}
+
+func (x *ListStepsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStepsRequest.ProtoReflect.Descriptor instead.
+func (*ListStepsRequest) Descriptor() ([]byte, []int) {
+	return file_progress_service_proto_rawDescGZIP(), []int{1}
+}
+
+type ListStepsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Steps         []*progresspb.Step     `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStepsResponse) Reset() {
+	*x = ListStepsResponse{}
+	mi := &file_progress_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStepsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStepsResponse) ProtoMessage() {}
+
+func (x *ListStepsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStepsResponse.ProtoReflect.Descriptor instead.
+func (*ListStepsResponse) Descriptor() ([]byte, []int) {
+	return file_progress_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListStepsResponse) GetSteps() []*progresspb.Step {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+type WatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_progress_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_progress_service_proto_rawDescGZIP(), []int{3}
+}
+
+type WatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Step          *progresspb.Step       `protobuf:"bytes,1,opt,name=step,proto3" json:"step,omitempty"`
+	Snapshot      *progresspb.Snapshot   `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchResponse) Reset() {
+	*x = WatchResponse{}
+	mi := &file_progress_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchResponse) ProtoMessage() {}
+
+func (x *WatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchResponse.ProtoReflect.Descriptor instead.
+func (*WatchResponse) Descriptor() ([]byte, []int) {
+	return file_progress_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WatchResponse) GetStep() *progresspb.Step {
+	if x != nil {
+		return x.Step
+	}
+	return nil
+}
+
+func (x *WatchResponse) GetSnapshot() *progresspb.Snapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+var File_progress_service_proto protoreflect.FileDescriptor
+
+const file_progress_service_proto_rawDesc = "" +
+	"\n" +
+	"\x16progress_service.proto\x12\bprogress\x1a\x0eprogress.proto\"\x14\n" +
+	"\x12GetSnapshotRequest\"\x12\n" +
+	"\x10ListStepsRequest\"9\n" +
+	"\x11ListStepsResponse\x12$\n" +
+	"\x05steps\x18\x01 \x03(\v2\x0e.progress.StepR\x05steps\"\x0e\n" +
+	"\fWatchRequest\"c\n" +
+	"\rWatchResponse\x12\"\n" +
+	"\x04step\x18\x01 \x01(\v2\x0e.progress.StepR\x04step\x12.\n" +
+	"\bsnapshot\x18\x02 \x01(\v2\x12.progress.SnapshotR\bsnapshot2\xd4\x01\n" +
+	"\x0fProgressService\x12?\n" +
+	"\vGetSnapshot\x12\x1c.progress.GetSnapshotRequest\x1a\x12.progress.Snapshot\x12D\n" +
+	"\tListSteps\x12\x1a.progress.ListStepsRequest\x1a\x1b.progress.ListStepsResponse\x12:\n" +
+	"\x05Watch\x12\x16.progress.WatchRequest\x1a\x17.progress.WatchResponse0\x01B\x1fZ\x1dmoul.io/progress/progressgrpcb\x06proto3"
+
+var (
+	file_progress_service_proto_rawDescOnce sync.Once
+	file_progress_service_proto_rawDescData []byte
+)
+
+func file_progress_service_proto_rawDescGZIP() []byte {
+	file_progress_service_proto_rawDescOnce.Do(func() {
+		file_progress_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_progress_service_proto_rawDesc), len(file_progress_service_proto_rawDesc)))
+	})
+	return file_progress_service_proto_rawDescData
+}
+
+var file_progress_service_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_progress_service_proto_goTypes = []any{
+	(*GetSnapshotRequest)(nil),  // 0: progress.GetSnapshotRequest
+	(*ListStepsRequest)(nil),    // 1: progress.ListStepsRequest
+	(*ListStepsResponse)(nil),   // 2: progress.ListStepsResponse
+	(*WatchRequest)(nil),        // 3: progress.WatchRequest
+	(*WatchResponse)(nil),       // 4: progress.WatchResponse
+	(*progresspb.Step)(nil),     // 5: progress.Step
+	(*progresspb.Snapshot)(nil), // 6: progress.Snapshot
+}
+var file_progress_service_proto_depIdxs = []int32{
+	5, // 0: progress.ListStepsResponse.steps:type_name -> progress.Step
+	5, // 1: progress.WatchResponse.step:type_name -> progress.Step
+	6, // 2: progress.WatchResponse.snapshot:type_name -> progress.Snapshot
+	0, // 3: progress.ProgressService.GetSnapshot:input_type -> progress.GetSnapshotRequest
+	1, // 4: progress.ProgressService.ListSteps:input_type -> progress.ListStepsRequest
+	3, // 5: progress.ProgressService.Watch:input_type -> progress.WatchRequest
+	6, // 6: progress.ProgressService.GetSnapshot:output_type -> progress.Snapshot
+	2, // 7: progress.ProgressService.ListSteps:output_type -> progress.ListStepsResponse
+	4, // 8: progress.ProgressService.Watch:output_type -> progress.WatchResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_progress_service_proto_init() }
+func file_progress_service_proto_init() {
+	if File_progress_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_progress_service_proto_rawDesc), len(file_progress_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_progress_service_proto_goTypes,
+		DependencyIndexes: file_progress_service_proto_depIdxs,
+		MessageInfos:      file_progress_service_proto_msgTypes,
+	}.Build()
+	File_progress_service_proto = out.File
+	file_progress_service_proto_goTypes = nil
+	file_progress_service_proto_depIdxs = nil
+}
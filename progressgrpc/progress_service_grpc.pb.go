@@ -0,0 +1,214 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.3
+// source: progress_service.proto
+
+package progressgrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	progresspb "moul.io/progress/progresspb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ProgressService_GetSnapshot_FullMethodName = "/progress.ProgressService/GetSnapshot"
+	ProgressService_ListSteps_FullMethodName   = "/progress.ProgressService/ListSteps"
+	ProgressService_Watch_FullMethodName       = "/progress.ProgressService/Watch"
+)
+
+// ProgressServiceClient is the client API for ProgressService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ProgressService exposes a progress over gRPC.
+type ProgressServiceClient interface {
+	// GetSnapshot returns the current snapshot.
+	GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*progresspb.Snapshot, error)
+	// ListSteps returns the steps.
+	ListSteps(ctx context.Context, in *ListStepsRequest, opts ...grpc.CallOption) (*ListStepsResponse, error)
+	// Watch streams the current snapshot, then each changed step with the new snapshot.
+	// The stream ends when the progress becomes terminal.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchResponse], error)
+}
+
+type progressServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProgressServiceClient(cc grpc.ClientConnInterface) ProgressServiceClient {
+	return &progressServiceClient{cc}
+}
+
+func (c *progressServiceClient) GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*progresspb.Snapshot, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(progresspb.Snapshot)
+	err := c.cc.Invoke(ctx, ProgressService_GetSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressServiceClient) ListSteps(ctx context.Context, in *ListStepsRequest, opts ...grpc.CallOption) (*ListStepsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStepsResponse)
+	err := c.cc.Invoke(ctx, ProgressService_ListSteps_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *progressServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProgressService_ServiceDesc.Streams[0], ProgressService_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, WatchResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProgressService_WatchClient = grpc.ServerStreamingClient[WatchResponse]
+
+// ProgressServiceServer is the server API for ProgressService service.
+// All implementations must embed UnimplementedProgressServiceServer
+// for forward compatibility.
+//
+// ProgressService exposes a progress over gRPC.
+type ProgressServiceServer interface {
+	// GetSnapshot returns the current snapshot.
+	GetSnapshot(context.Context, *GetSnapshotRequest) (*progresspb.Snapshot, error)
+	// ListSteps returns the steps.
+	ListSteps(context.Context, *ListStepsRequest) (*ListStepsResponse, error)
+	// Watch streams the current snapshot, then each changed step with the new snapshot.
+	// The stream ends when the progress becomes terminal.
+	Watch(*WatchRequest, grpc.ServerStreamingServer[WatchResponse]) error
+	mustEmbedUnimplementedProgressServiceServer()
+}
+
+// UnimplementedProgressServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedProgressServiceServer struct{}
+
+func (UnimplementedProgressServiceServer) GetSnapshot(context.Context, *GetSnapshotRequest) (*progresspb.Snapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSnapshot not implemented")
+}
+func (UnimplementedProgressServiceServer) ListSteps(context.Context, *ListStepsRequest) (*ListStepsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSteps not implemented")
+}
+func (UnimplementedProgressServiceServer) Watch(*WatchRequest, grpc.ServerStreamingServer[WatchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedProgressServiceServer) mustEmbedUnimplementedProgressServiceServer() {}
+func (UnimplementedProgressServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeProgressServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProgressServiceServer will
+// result in compilation errors.
+type UnsafeProgressServiceServer interface {
+	mustEmbedUnimplementedProgressServiceServer()
+}
+
+func RegisterProgressServiceServer(s grpc.ServiceRegistrar, srv ProgressServiceServer) {
+	// If the following call pancis, it indicates UnimplementedProgressServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ProgressService_ServiceDesc, srv)
+}
+
+func _ProgressService_GetSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressServiceServer).GetSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProgressService_GetSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressServiceServer).GetSnapshot(ctx, req.(*GetSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressService_ListSteps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStepsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProgressServiceServer).ListSteps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProgressService_ListSteps_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProgressServiceServer).ListSteps(ctx, req.(*ListStepsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProgressService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProgressServiceServer).Watch(m, &grpc.GenericServerStream[WatchRequest, WatchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProgressService_WatchServer = grpc.ServerStreamingServer[WatchResponse]
+
+// ProgressService_ServiceDesc is the grpc.ServiceDesc for ProgressService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProgressService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "progress.ProgressService",
+	HandlerType: (*ProgressServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSnapshot",
+			Handler:    _ProgressService_GetSnapshot_Handler,
+		},
+		{
+			MethodName: "ListSteps",
+			Handler:    _ProgressService_ListSteps_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _ProgressService_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "progress_service.proto",
+}
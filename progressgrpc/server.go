@@ -0,0 +1,80 @@
+package progressgrpc
+
+import (
+	"context"
+
+	"moul.io/progress"
+	"moul.io/progress/progresspb"
+)
+
+// Server implements ProgressServiceServer by wrapping a Progress.
+type Server struct {
+	UnimplementedProgressServiceServer
+
+	prog *progress.Progress
+}
+
+var _ ProgressServiceServer = (*Server)(nil)
+
+// NewServer returns a Server exposing 'prog'.
+func NewServer(prog *progress.Progress) *Server {
+	return &Server{prog: prog}
+}
+
+// GetSnapshot implements ProgressServiceServer.
+func (s *Server) GetSnapshot(context.Context, *GetSnapshotRequest) (*progresspb.Snapshot, error) {
+	return progresspb.SnapshotToProto(s.prog.Snapshot()), nil
+}
+
+// ListSteps implements ProgressServiceServer.
+func (s *Server) ListSteps(context.Context, *ListStepsRequest) (*ListStepsResponse, error) {
+	res := &ListStepsResponse{}
+	for step := range s.prog.AllSteps() {
+		pb, err := progresspb.StepToProto(step)
+		if err != nil {
+			return nil, err
+		}
+		res.Steps = append(res.Steps, pb)
+	}
+	return res, nil
+}
+
+// Watch implements ProgressServiceServer.
+func (s *Server) Watch(_ *WatchRequest, stream ProgressService_WatchServer) error {
+	// subscribe before sending the initial snapshot to avoid missing updates
+	subscriber := s.prog.Subscribe()
+	defer s.prog.Unsubscribe(subscriber)
+	// the subscriber of an already terminal Progress is never closed, the initial snapshot is the final one
+	terminal := s.prog.IsDone()
+
+	if err := stream.Send(&WatchResponse{Snapshot: progresspb.SnapshotToProto(s.prog.Snapshot())}); err != nil {
+		return err
+	}
+	if terminal {
+		return nil
+	}
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case step, ok := <-subscriber:
+			snapshot := progresspb.SnapshotToProto(s.prog.Snapshot())
+			if !ok {
+				return stream.Send(&WatchResponse{Snapshot: snapshot})
+			}
+			res := &WatchResponse{Snapshot: snapshot}
+			if step != nil {
+				pbStep, err := progresspb.StepToProto(step)
+				if err != nil {
+					return err
+				}
+				res.Step = pbStep
+			}
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+		}
+	}
+}
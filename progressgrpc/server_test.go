@@ -0,0 +1,95 @@
+package progressgrpc_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"moul.io/progress"
+	"moul.io/progress/progressgrpc"
+	"moul.io/progress/progresspb"
+)
+
+// newClient serves 'prog' in memory and returns a client connected to it.
+func newClient(t *testing.T, prog *progress.Progress) progressgrpc.ProgressServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	progressgrpc.RegisterProgressServiceServer(server, progressgrpc.NewServer(prog))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return progressgrpc.NewProgressServiceClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Start()
+	prog.AddStep("step2")
+	client := newClient(t, prog)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	snapshot, err := client.GetSnapshot(ctx, &progressgrpc.GetSnapshotRequest{})
+	require.NoError(t, err)
+	require.Equal(t, progresspb.State_STATE_IN_PROGRESS, snapshot.State)
+	require.Equal(t, int64(2), snapshot.Total)
+
+	steps, err := client.ListSteps(ctx, &progressgrpc.ListStepsRequest{})
+	require.NoError(t, err)
+	require.Len(t, steps.Steps, 2)
+	require.Equal(t, "step2", steps.Steps[1].Id)
+
+	stream, err := client.Watch(ctx, &progressgrpc.WatchRequest{})
+	require.NoError(t, err)
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.Nil(t, res.Step)
+	require.Equal(t, int64(2), res.Snapshot.Total)
+
+	prog.Get("step1").Done()
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "step1", res.Step.Id)
+	require.Equal(t, progresspb.State_STATE_DONE, res.Step.State)
+
+	prog.Get("step2").Done()
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "step2", res.Step.Id)
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	require.Nil(t, res.Step)
+	require.Equal(t, progresspb.State_STATE_DONE, res.Snapshot.State)
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestServer_watchTerminal(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Done()
+	client := newClient(t, prog)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.Watch(ctx, &progressgrpc.WatchRequest{})
+	require.NoError(t, err)
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, progresspb.State_STATE_DONE, res.Snapshot.State)
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+}
@@ -0,0 +1,154 @@
+// Package progressnotify provides a notifier posting human-readable Progress milestones to Slack or Discord.
+package progressnotify // import "moul.io/progress/progressnotify"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"moul.io/progress"
+)
+
+// Format is the payload format expected by the webhook.
+type Format int
+
+const (
+	// Slack sends {"text": "..."} payloads, for Slack incoming webhooks.
+	Slack Format = iota
+	// Discord sends {"content": "..."} payloads, for Discord webhooks.
+	Discord
+)
+
+var defaultMilestones = []float64{0.25, 0.5, 0.75}
+
+// Notifier posts messages to a chat webhook when milestones are reached.
+//
+// A message is sent when the completion crosses one of the Milestones, when a step is canceled
+// or fails, and when the Progress becomes terminal.
+type Notifier struct {
+	// URL is the webhook endpoint.
+	URL string
+	// Format is the payload format, Slack by default.
+	Format Format
+	// Name prefixes every message, e.g. the job name.
+	Name string
+	// Milestones are the completion ratios, between 0 and 1, triggering a message; 25%, 50% and 75% if nil.
+	Milestones []float64
+	// MinInterval is the minimum delay between two messages; messages sent sooner are dropped,
+	// except the final one. Zero disables throttling.
+	MinInterval time.Duration
+	// Client is used to send requests, http.DefaultClient if nil.
+	Client *http.Client
+}
+
+// Attach subscribes to 'prog' and posts messages until the Progress becomes terminal, 'ctx' is done,
+// or the returned stop function is called.
+// The stop function returns the first delivery error.
+func (n *Notifier) Attach(ctx context.Context, prog *progress.Progress) (stop func() error) {
+	milestones := n.Milestones
+	if milestones == nil {
+		milestones = defaultMilestones
+	}
+	milestones = append([]float64(nil), milestones...)
+	sort.Float64s(milestones)
+
+	subscriber := prog.SubscribeEvents()
+	done := make(chan struct{})
+	var notifyErr error
+	go func() {
+		defer close(done)
+		var (
+			lastSent time.Time
+			reached  int
+		)
+		notify := func(text string, force bool) {
+			if ctx.Err() != nil {
+				return
+			}
+			if !force && n.MinInterval > 0 && time.Since(lastSent) < n.MinInterval {
+				return
+			}
+			lastSent = time.Now()
+			if err := n.post(ctx, text); err != nil && notifyErr == nil {
+				notifyErr = err
+			}
+		}
+
+		for event := range subscriber {
+			switch event.To {
+			case progress.StateCanceled:
+				notify(fmt.Sprintf("%sstep %q canceled", n.prefix(), event.StepID), false)
+			case progress.StateFailed:
+				notify(fmt.Sprintf("%sstep %q failed: %s", n.prefix(), event.StepID, event.Step.Error), false)
+			}
+
+			snapshot := prog.Snapshot()
+			if snapshot.State.IsTerminal() {
+				continue
+			}
+			crossed := reached
+			for crossed < len(milestones) && milestones[crossed] < 1 && snapshot.Progress >= milestones[crossed] {
+				crossed++
+			}
+			if crossed > reached {
+				reached = crossed
+				notify(fmt.Sprintf("%s%.0f%% done (%d/%d)", n.prefix(), milestones[crossed-1]*100, snapshot.Completed, snapshot.Total), false)
+			}
+		}
+
+		snapshot := prog.Snapshot()
+		switch snapshot.State {
+		case progress.StateDone:
+			notify(fmt.Sprintf("%sdone in %s", n.prefix(), snapshot.TotalDuration.Round(time.Second)), true)
+		case progress.StateFailed:
+			notify(fmt.Sprintf("%sfailed after %s (%d/%d done)", n.prefix(), snapshot.TotalDuration.Round(time.Second), snapshot.Completed, snapshot.Total), true)
+		case progress.StateCanceled:
+			notify(fmt.Sprintf("%scanceled after %s (%d/%d done)", n.prefix(), snapshot.TotalDuration.Round(time.Second), snapshot.Completed, snapshot.Total), true)
+		}
+	}()
+	return func() error {
+		prog.UnsubscribeEvents(subscriber)
+		<-done
+		return notifyErr
+	}
+}
+
+func (n *Notifier) prefix() string {
+	if n.Name == "" {
+		return ""
+	}
+	return n.Name + ": "
+}
+
+func (n *Notifier) post(ctx context.Context, text string) error {
+	key := "text"
+	if n.Format == Discord {
+		key = "content"
+	}
+	body, err := json.Marshal(map[string]string{key: text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("progressnotify: unexpected status %q", res.Status)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package progressnotify_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progressnotify"
+)
+
+func newServer(t *testing.T, key string) (*httptest.Server, func() []string) {
+	var (
+		mutex    sync.Mutex
+		messages []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		mutex.Lock()
+		defer mutex.Unlock()
+		messages = append(messages, payload[key])
+	}))
+	return server, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return messages
+	}
+}
+
+func TestNotifier(t *testing.T) {
+	server, messages := newServer(t, "text")
+	defer server.Close()
+
+	prog := progress.New()
+	for _, id := range []string{"step1", "step2", "step3", "step4"} {
+		prog.AddStep(id)
+	}
+	notifier := progressnotify.Notifier{URL: server.URL, Name: "myjob"}
+	stop := notifier.Attach(context.Background(), prog)
+	// wait for each message, as milestones are computed from the current snapshot
+	prog.Get("step1").Done()
+	require.Eventually(t, func() bool { return len(messages()) == 1 }, time.Second, time.Millisecond)
+	prog.Get("step2").Done()
+	require.Eventually(t, func() bool { return len(messages()) == 2 }, time.Second, time.Millisecond)
+	prog.Get("step3").Cancel()
+	require.Eventually(t, func() bool { return len(messages()) == 3 }, time.Second, time.Millisecond)
+	prog.Get("step4").Done()
+	require.NoError(t, stop())
+
+	require.Equal(t, []string{
+		"myjob: 25% done (1/4)",
+		"myjob: 50% done (2/4)",
+		`myjob: step "step3" canceled`,
+		"myjob: canceled after 0s (3/4 done)",
+	}, messages())
+}
+
+func TestNotifier_throttling(t *testing.T) {
+	server, messages := newServer(t, "content")
+	defer server.Close()
+
+	prog := progress.New()
+	for _, id := range []string{"step1", "step2", "step3", "step4"} {
+		prog.AddStep(id)
+	}
+	notifier := progressnotify.Notifier{URL: server.URL, Format: progressnotify.Discord, MinInterval: time.Hour}
+	stop := notifier.Attach(context.Background(), prog)
+	prog.Get("step1").Done()
+	require.Eventually(t, func() bool { return len(messages()) == 1 }, time.Second, time.Millisecond)
+	for _, id := range []string{"step2", "step3", "step4"} {
+		prog.Get(id).Done()
+	}
+	require.NoError(t, stop())
+
+	require.Equal(t, []string{"25% done (1/4)", "done in 0s"}, messages())
+}
@@ -0,0 +1,2 @@
+// Package progressotel provides OpenTelemetry integrations exposing a Progress as traces and metrics.
+package progressotel // import "moul.io/progress/progressotel"
@@ -0,0 +1,81 @@
+package progressotel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/metric"
+	"moul.io/progress"
+)
+
+// RecordMetrics reports 'prog' metrics using 'meter':
+//
+//	progress.ratio            gauge, completion between 0 and 1.
+//	progress.steps.completed  counter, number of done steps.
+//	progress.steps.canceled   counter, number of canceled steps.
+//	progress.steps.failed     counter, number of failed steps.
+//	progress.step.duration    histogram, duration in seconds of each done step.
+//
+// The returned stop function unregisters the instruments.
+func RecordMetrics(prog *progress.Progress, meter metric.Meter) (stop func() error, err error) {
+	ratio, err := meter.Float64ObservableGauge("progress.ratio",
+		metric.WithDescription("Completion of the progress, between 0 and 1."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	completed, err := meter.Int64ObservableCounter("progress.steps.completed",
+		metric.WithDescription("Number of done steps."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	canceled, err := meter.Int64ObservableCounter("progress.steps.canceled",
+		metric.WithDescription("Number of canceled steps."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	failed, err := meter.Int64ObservableCounter("progress.steps.failed",
+		metric.WithDescription("Number of failed steps."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	duration, err := meter.Float64Histogram("progress.step.duration",
+		metric.WithDescription("Duration of each done step."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	registration, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		snapshot := prog.Snapshot()
+		o.ObserveFloat64(ratio, snapshot.Progress)
+		o.ObserveInt64(completed, int64(snapshot.Completed))
+		o.ObserveInt64(canceled, int64(snapshot.Canceled))
+		o.ObserveInt64(failed, int64(snapshot.Failed))
+		return nil
+	}, ratio, completed, canceled, failed)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriber := prog.Subscribe(progress.FilterStates(progress.StateDone))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for step := range subscriber {
+			if step == nil {
+				continue
+			}
+			duration.Record(context.Background(), step.Duration().Seconds())
+		}
+	}()
+
+	return func() error {
+		prog.Unsubscribe(subscriber)
+		<-done
+		return registration.Unregister()
+	}, nil
+}
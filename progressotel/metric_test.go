@@ -0,0 +1,69 @@
+package progressotel_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"moul.io/progress"
+	"moul.io/progress/progressotel"
+)
+
+func TestRecordMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	prog := progress.New()
+	prog.AddStep("step1")
+	prog.AddStep("step2")
+	prog.AddStep("step3")
+	stop, err := progressotel.RecordMetrics(prog, provider.Meter("test"))
+	require.NoError(t, err)
+
+	prog.Get("step1").Start()
+	prog.Get("step1").Done()
+	prog.Get("step2").Cancel()
+	require.NoError(t, stop())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	metrics := map[string]metricdata.Aggregation{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m.Data
+	}
+
+	// callbacks are unregistered by stop, only the histogram remains
+	require.NotContains(t, metrics, "progress.ratio")
+	histogram := metrics["progress.step.duration"].(metricdata.Histogram[float64])
+	require.Len(t, histogram.DataPoints, 1)
+	require.Equal(t, uint64(1), histogram.DataPoints[0].Count)
+}
+
+func TestRecordMetrics_observe(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	prog := progress.New()
+	prog.AddStep("step1").Done()
+	prog.AddStep("step2").Cancel()
+	prog.AddStep("step3")
+	prog.AddStep("step4")
+	stop, err := progressotel.RecordMetrics(prog, provider.Meter("test"))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, stop()) }()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	metrics := map[string]metricdata.Aggregation{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m.Data
+	}
+	require.Equal(t, 0.25, metrics["progress.ratio"].(metricdata.Gauge[float64]).DataPoints[0].Value)
+	require.Equal(t, int64(1), metrics["progress.steps.completed"].(metricdata.Sum[int64]).DataPoints[0].Value)
+	require.Equal(t, int64(1), metrics["progress.steps.canceled"].(metricdata.Sum[int64]).DataPoints[0].Value)
+}
@@ -0,0 +1,148 @@
+package progressotel
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"moul.io/progress"
+)
+
+// Trace records 'prog' as a trace: a root span named 'name', started from 'ctx', with a child span per step.
+//
+// A step span starts when the step starts and ends when it is done, canceled, failed, or interrupted;
+// canceled and failed steps have an error status, and the error of failed steps is recorded on their span.
+// The steps of a Progress added with AddChild are recorded the same way, as child spans of the span of
+// the step tracking it.
+// The root span ends when the Progress becomes terminal, right away if it is already terminal, or when the
+// returned stop function is called, which blocks until every started span has ended.
+// The timestamps are taken from the clock of the Progress.
+func Trace(ctx context.Context, prog *progress.Progress, tracer trace.Tracer, name string) (stop func()) {
+	subscriber := prog.Subscribe()
+	if prog.IsDone() {
+		// the subscriber of a done Progress is never closed, only the steps already recorded are traced
+		prog.Unsubscribe(subscriber)
+	}
+	clock := prog.Clock()
+	ctx, root := tracer.Start(ctx, name, trace.WithTimestamp(clock.Now()))
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		traceSteps(ctx, prog, tracer, subscriber)
+
+		snapshot := prog.Snapshot()
+		root.SetAttributes(
+			attribute.String("progress.state", string(snapshot.State)),
+			attribute.Int("progress.total", snapshot.Total),
+			attribute.Int("progress.completed", snapshot.Completed),
+		)
+		if snapshot.State == progress.StateCanceled || snapshot.State == progress.StateFailed {
+			root.SetStatus(codes.Error, string(snapshot.State))
+		}
+		root.End(trace.WithTimestamp(clock.Now()))
+	}()
+
+	return func() {
+		prog.Unsubscribe(subscriber)
+		<-done
+	}
+}
+
+// stepSpan is the span of a started step, with the stop function of the tracing of its child Progress, if any.
+type stepSpan struct {
+	span      trace.Span
+	startedAt time.Time
+	stopChild func()
+}
+
+func (s *stepSpan) end(options ...trace.SpanEndOption) {
+	if s.stopChild != nil {
+		s.stopChild()
+	}
+	s.span.End(options...)
+}
+
+// traceSteps records the steps of 'prog' as spans started from 'ctx', until 'subscriber' is closed.
+func traceSteps(ctx context.Context, prog *progress.Progress, tracer trace.Tracer, subscriber chan *progress.Step) {
+	var (
+		spans = make(map[string]*stepSpan)
+		ended = make(map[string]time.Time) // the start time of the last ended span of each step
+	)
+	handle := func(step *progress.Step) {
+		current, started := spans[step.ID]
+		if !started && step.StartedAt != nil && step.State != progress.StateNotStarted {
+			if startedAt, found := ended[step.ID]; found && startedAt.Equal(*step.StartedAt) {
+				return
+			}
+			spanCtx, span := tracer.Start(ctx, step.ID,
+				trace.WithTimestamp(*step.StartedAt),
+				trace.WithAttributes(attribute.String("progress.step.id", step.ID)),
+			)
+			current = &stepSpan{span: span, startedAt: *step.StartedAt}
+			if child := step.Child(); child != nil {
+				current.stopChild = traceChild(spanCtx, child, tracer)
+			}
+			spans[step.ID] = current
+			started = true
+		}
+		if !started {
+			return
+		}
+		span := current.span
+		if step.Description != "" {
+			span.SetAttributes(attribute.String("progress.step.description", step.Description))
+		}
+		switch step.State {
+		case progress.StateDone:
+			span.SetStatus(codes.Ok, "")
+		case progress.StateCanceled:
+			span.SetStatus(codes.Error, string(progress.StateCanceled))
+		case progress.StateFailed:
+			span.RecordError(errors.New(step.Error))
+			span.SetStatus(codes.Error, step.Error)
+		case progress.StateInterrupted:
+			span.SetAttributes(attribute.Bool("progress.step.interrupted", true))
+		default:
+			return
+		}
+		end := prog.Clock().Now()
+		if step.DoneAt != nil {
+			end = *step.DoneAt
+		}
+		current.end(trace.WithTimestamp(end))
+		delete(spans, step.ID)
+		ended[step.ID] = current.startedAt
+	}
+
+	// the steps already started before subscribing, i.e., in a child Progress, are recorded first
+	for step := range prog.AllSteps() {
+		handle(step)
+	}
+	for step := range subscriber {
+		if step != nil {
+			handle(step)
+		}
+	}
+
+	for _, current := range spans {
+		current.end(trace.WithTimestamp(prog.Clock().Now()))
+	}
+}
+
+// traceChild records the steps of 'child' as spans started from 'ctx' until the returned stop function is called.
+func traceChild(ctx context.Context, child *progress.Progress, tracer trace.Tracer) (stop func()) {
+	subscriber := child.Subscribe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		traceSteps(ctx, child, tracer, subscriber)
+	}()
+	return func() {
+		child.Unsubscribe(subscriber)
+		<-done
+	}
+}
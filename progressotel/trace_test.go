@@ -0,0 +1,169 @@
+package progressotel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"moul.io/progress"
+	"moul.io/progress/progressotel"
+	"moul.io/progress/progresstest"
+)
+
+func TestTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	prog := progress.New()
+	prog.AddStep("step1")
+	prog.AddStep("step2")
+	prog.AddStep("step3")
+	stop := progressotel.Trace(context.Background(), prog, provider.Tracer("test"), "job")
+
+	prog.Get("step1").Start()
+	prog.Get("step1").Done()
+	prog.Get("step2").Start()
+	prog.Get("step2").Cancel()
+	prog.Get("step3").Cancel()
+	stop()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	byName := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range spans {
+		byName[span.Name()] = span
+	}
+	root := byName["job"]
+	require.NotNil(t, root)
+	require.Equal(t, codes.Error, root.Status().Code)
+
+	step1 := byName["step1"]
+	require.Equal(t, codes.Ok, step1.Status().Code)
+	require.Equal(t, root.SpanContext().SpanID(), step1.Parent().SpanID())
+	require.Equal(t, *prog.Get("step1").StartedAt, step1.StartTime())
+	require.Equal(t, *prog.Get("step1").DoneAt, step1.EndTime())
+
+	step2 := byName["step2"]
+	require.Equal(t, codes.Error, step2.Status().Code)
+
+	// step3 was never started
+	require.NotContains(t, byName, "step3")
+}
+
+func TestTrace_failed(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	prog := progress.New()
+	stop := progressotel.Trace(context.Background(), prog, provider.Tracer("test"), "job")
+	prog.AddStep("step1").Start().Fail(errors.New("boom"))
+	stop()
+
+	var step1 sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == "step1" {
+			step1 = span
+		}
+	}
+	require.NotNil(t, step1)
+	require.Equal(t, codes.Error, step1.Status().Code)
+	require.Equal(t, "boom", step1.Status().Description)
+	require.Len(t, step1.Events(), 1)
+	require.Equal(t, "exception", step1.Events()[0].Name)
+	require.Contains(t, step1.Events()[0].Attributes, attribute.String("exception.message", "boom"))
+}
+
+func TestTrace_children(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	child := progress.New()
+	child.AddStep("fetch")
+	child.AddStep("build")
+	prog := progress.New()
+	prog.AddChild("deploy", child)
+	stop := progressotel.Trace(context.Background(), prog, provider.Tracer("test"), "job")
+
+	child.Get("fetch").Start()
+	child.Get("fetch").Done()
+	child.Get("build").Start()
+	child.Get("build").Done()
+	select {
+	case <-prog.DoneCh():
+	case <-time.After(time.Second):
+		t.Fatal("the parent progress should be done with its child")
+	}
+	stop()
+
+	byName := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		byName[span.Name()] = span
+	}
+	require.Len(t, byName, 4)
+	deploy := byName["deploy"]
+	require.NotNil(t, deploy)
+	require.Equal(t, byName["job"].SpanContext().SpanID(), deploy.Parent().SpanID())
+	for _, name := range []string{"fetch", "build"} {
+		span := byName[name]
+		require.NotNil(t, span, name)
+		require.Equal(t, deploy.SpanContext().SpanID(), span.Parent().SpanID(), name)
+		require.Equal(t, codes.Ok, span.Status().Code, name)
+		require.Equal(t, *child.Get(name).DoneAt, span.EndTime(), name)
+	}
+}
+
+func TestTrace_clock(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	startedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := progresstest.NewClock(startedAt)
+	prog := progress.New(progress.WithClock(clock))
+	prog.AddStep("step1")
+	prog.AddStep("step2")
+	stop := progressotel.Trace(context.Background(), prog, provider.Tracer("test"), "job")
+	prog.Get("step1").Start()
+	prog.Get("step2").Start()
+	clock.Advance(time.Minute)
+	prog.Get("step1").Done()
+	stop() // step2 is still running
+
+	byName := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		byName[span.Name()] = span
+	}
+	require.Len(t, byName, 3)
+	require.Equal(t, startedAt, byName["job"].StartTime())
+	require.Equal(t, startedAt.Add(time.Minute), byName["job"].EndTime())
+	require.Equal(t, startedAt.Add(time.Minute), byName["step2"].EndTime())
+}
+
+func TestTrace_terminal(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	prog := progress.New()
+	prog.AddStep("step1").Start().Done()
+	stop := progressotel.Trace(context.Background(), prog, provider.Tracer("test"), "job")
+	defer stop()
+
+	// the root span ends without waiting for the stop function
+	require.Eventually(t, func() bool { return len(recorder.Ended()) == 2 }, time.Second, time.Millisecond)
+	byName := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		byName[span.Name()] = span
+	}
+	require.Contains(t, byName, "job")
+	require.Equal(t, codes.Ok, byName["step1"].Status().Code)
+}
@@ -0,0 +1,280 @@
+package progresspb
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"moul.io/progress"
+)
+
+var stateToProto = map[progress.State]State{
+	progress.StateNotStarted:  State_STATE_NOT_STARTED,
+	progress.StateInProgress:  State_STATE_IN_PROGRESS,
+	progress.StateDone:        State_STATE_DONE,
+	progress.StateStopped:     State_STATE_STOPPED,
+	progress.StateCanceled:    State_STATE_CANCELED,
+	progress.StateInterrupted: State_STATE_INTERRUPTED,
+	progress.StateFailed:      State_STATE_FAILED,
+	progress.StateSkipped:     State_STATE_SKIPPED,
+}
+
+var stateFromProto = func() map[State]progress.State {
+	ret := make(map[State]progress.State, len(stateToProto))
+	for native, pb := range stateToProto {
+		ret[pb] = native
+	}
+	return ret
+}()
+
+// StateToProto converts a progress.State into its protobuf representation.
+// Unknown states are converted to State_STATE_UNSPECIFIED.
+func StateToProto(state progress.State) State {
+	return stateToProto[state]
+}
+
+// StateFromProto converts a protobuf State into a progress.State.
+// State_STATE_UNSPECIFIED and unknown values are converted to an empty state.
+func StateFromProto(state State) progress.State {
+	return stateFromProto[state]
+}
+
+// ToProto converts a Progress into its protobuf representation, including the current snapshot.
+func ToProto(prog *progress.Progress) (*Progress, error) {
+	ret := &Progress{
+		CreatedAt: timeToProto(prog.CreatedAt),
+		Snapshot:  SnapshotToProto(prog.Snapshot()),
+	}
+	for step := range prog.AllSteps() {
+		pbStep, err := StepToProto(step)
+		if err != nil {
+			return nil, err
+		}
+		ret.Steps = append(ret.Steps, pbStep)
+	}
+	for _, event := range prog.Events() {
+		ret.Events = append(ret.Events, &Event{
+			StepId: event.StepID,
+			From:   StateToProto(event.From),
+			To:     StateToProto(event.To),
+			At:     timeToProto(event.At),
+			Actor:  event.Actor,
+		})
+	}
+	return ret, nil
+}
+
+// FromProto converts a protobuf Progress into a Progress ready to be used with the native helpers.
+// The snapshot is ignored, it is computed again on demand.
+func FromProto(pb *Progress) (*progress.Progress, error) {
+	type payload struct {
+		Steps     []*progress.Step `json:"steps,omitempty"`
+		CreatedAt time.Time        `json:"created_at,omitempty"`
+		Events    []progress.Event `json:"events,omitempty"`
+	}
+	decoded := payload{CreatedAt: timeFromProto(pb.GetCreatedAt())}
+	for _, pbStep := range pb.GetSteps() {
+		decoded.Steps = append(decoded.Steps, StepFromProto(pbStep))
+	}
+	for _, pbEvent := range pb.GetEvents() {
+		decoded.Events = append(decoded.Events, progress.Event{
+			StepID: pbEvent.GetStepId(),
+			From:   StateFromProto(pbEvent.GetFrom()),
+			To:     StateFromProto(pbEvent.GetTo()),
+			At:     timeFromProto(pbEvent.GetAt()),
+			Actor:  pbEvent.GetActor(),
+		})
+	}
+
+	// the JSON representation is used as a pivot to attach the steps and the events to the Progress
+	raw, err := json.Marshal(decoded)
+	if err != nil {
+		return nil, err
+	}
+	var prog progress.Progress
+	if err := json.Unmarshal(raw, &prog); err != nil {
+		return nil, err
+	}
+	return &prog, nil
+}
+
+// StepToProto converts a Step into its protobuf representation.
+// The step data should be representable as JSON.
+func StepToProto(step *progress.Step) (*Step, error) {
+	ret := &Step{
+		Id:          step.ID,
+		Description: step.Description,
+		StartedAt:   timestampToProto(step.StartedAt),
+		DoneAt:      timestampToProto(step.DoneAt),
+		State:       StateToProto(step.State),
+		Progress:    step.Progress,
+		Actor:       step.Actor,
+		Group:       step.Group,
+		Tags:        step.Tags,
+		Metadata:    step.Metadata,
+		Duration:    durationToProto(step.Duration()),
+		Error:       step.Error,
+		SkipReason:  step.SkipReason,
+		Units:       step.Units,
+		TotalUnits:  step.TotalUnits,
+	}
+	for _, attempt := range step.Attempts {
+		ret.Attempts = append(ret.Attempts, &Attempt{
+			StartedAt: timeToProto(attempt.StartedAt),
+			DoneAt:    timeToProto(attempt.DoneAt),
+			Error:     attempt.Error,
+		})
+	}
+	for _, line := range step.Logs {
+		ret.Logs = append(ret.Logs, &LogLine{At: timeToProto(line.At), Message: line.Message})
+	}
+	if step.Data != nil {
+		data, err := dataToProto(step.Data)
+		if err != nil {
+			return nil, fmt.Errorf("step %q: %w", step.ID, err)
+		}
+		ret.Data = data
+	}
+	return ret, nil
+}
+
+// StepFromProto converts a protobuf Step into a detached Step.
+func StepFromProto(pb *Step) *progress.Step {
+	step := &progress.Step{
+		ID:          pb.GetId(),
+		Description: pb.GetDescription(),
+		StartedAt:   timestampFromProto(pb.GetStartedAt()),
+		DoneAt:      timestampFromProto(pb.GetDoneAt()),
+		State:       StateFromProto(pb.GetState()),
+		Data:        pb.GetData().AsInterface(),
+		Progress:    pb.GetProgress(),
+		Actor:       pb.GetActor(),
+		Group:       pb.GetGroup(),
+		Tags:        pb.GetTags(),
+		Metadata:    pb.GetMetadata(),
+		Error:       pb.GetError(),
+		SkipReason:  pb.GetSkipReason(),
+		Units:       pb.GetUnits(),
+		TotalUnits:  pb.GetTotalUnits(),
+	}
+	for _, attempt := range pb.GetAttempts() {
+		step.Attempts = append(step.Attempts, progress.Attempt{
+			StartedAt: timeFromProto(attempt.GetStartedAt()),
+			DoneAt:    timeFromProto(attempt.GetDoneAt()),
+			Error:     attempt.GetError(),
+		})
+	}
+	for _, line := range pb.GetLogs() {
+		step.Logs = append(step.Logs, progress.LogLine{At: timeFromProto(line.GetAt()), Message: line.GetMessage()})
+	}
+	return step
+}
+
+// SnapshotToProto converts a Snapshot into its protobuf representation.
+func SnapshotToProto(snapshot progress.Snapshot) *Snapshot {
+	return &Snapshot{
+		State:              StateToProto(snapshot.State),
+		Doing:              snapshot.Doing,
+		NotStarted:         int64(snapshot.NotStarted),
+		InProgress:         int64(snapshot.InProgress),
+		Completed:          int64(snapshot.Completed),
+		Canceled:           int64(snapshot.Canceled),
+		Interrupted:        int64(snapshot.Interrupted),
+		Failed:             int64(snapshot.Failed),
+		Skipped:            int64(snapshot.Skipped),
+		Total:              int64(snapshot.Total),
+		Progress:           snapshot.Progress,
+		TotalDuration:      durationToProto(snapshot.TotalDuration),
+		StepDuration:       durationToProto(snapshot.StepDuration),
+		CompletionEstimate: durationToProto(snapshot.CompletionEstimate),
+		DoneAt:             timestampToProto(snapshot.DoneAt),
+		StartedAt:          timestampToProto(snapshot.StartedAt),
+		Indeterminate:      snapshot.Indeterminate,
+		Stale:              snapshot.Stale,
+		StaleSteps:         snapshot.StaleSteps,
+		Outcome:            string(snapshot.Outcome),
+	}
+}
+
+// SnapshotFromProto converts a protobuf Snapshot into a Snapshot.
+func SnapshotFromProto(pb *Snapshot) progress.Snapshot {
+	return progress.Snapshot{
+		State:              StateFromProto(pb.GetState()),
+		Doing:              pb.GetDoing(),
+		NotStarted:         int(pb.GetNotStarted()),
+		InProgress:         int(pb.GetInProgress()),
+		Completed:          int(pb.GetCompleted()),
+		Canceled:           int(pb.GetCanceled()),
+		Interrupted:        int(pb.GetInterrupted()),
+		Failed:             int(pb.GetFailed()),
+		Skipped:            int(pb.GetSkipped()),
+		Total:              int(pb.GetTotal()),
+		Progress:           pb.GetProgress(),
+		TotalDuration:      pb.GetTotalDuration().AsDuration(),
+		StepDuration:       pb.GetStepDuration().AsDuration(),
+		CompletionEstimate: pb.GetCompletionEstimate().AsDuration(),
+		DoneAt:             timestampFromProto(pb.GetDoneAt()),
+		StartedAt:          timestampFromProto(pb.GetStartedAt()),
+		Indeterminate:      pb.GetIndeterminate(),
+		Stale:              pb.GetStale(),
+		StaleSteps:         pb.GetStaleSteps(),
+		Outcome:            progress.Outcome(pb.GetOutcome()),
+	}
+}
+
+func dataToProto(data interface{}) (*structpb.Value, error) {
+	if value, err := structpb.NewValue(data); err == nil {
+		return value, nil
+	}
+	// fallback to the JSON representation for custom types
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, err
+	}
+	return structpb.NewValue(generic)
+}
+
+func timestampToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timeToProto(*t)
+}
+
+func timestampFromProto(pb *timestamppb.Timestamp) *time.Time {
+	if pb == nil {
+		return nil
+	}
+	t := pb.AsTime()
+	return &t
+}
+
+// timeToProto converts a zero time into a nil timestamp, see timeFromProto.
+func timeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// timeFromProto converts a nil timestamp into a zero time, instead of the Unix epoch returned by AsTime.
+func timeFromProto(pb *timestamppb.Timestamp) time.Time {
+	if pb == nil {
+		return time.Time{}
+	}
+	return pb.AsTime()
+}
+
+func durationToProto(d time.Duration) *durationpb.Duration {
+	if d == 0 {
+		return nil
+	}
+	return durationpb.New(d)
+}
@@ -0,0 +1,125 @@
+package progresspb_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"moul.io/progress"
+	"moul.io/progress/progresspb"
+)
+
+func TestRoundTrip(t *testing.T) {
+	type custom struct {
+		Foo string `json:"foo"`
+	}
+	prog := progress.New()
+	prog.AddStep("step1").SetDescription("hello").SetData(42).Done()
+	prog.AddStep("step2").SetData(custom{Foo: "bar"}).SetActor("worker-1").Start().AddUnits(5)
+	prog.AddStep("step3").AddTag("cleanup").SetMeta("region", "eu")
+	prog.Get("step3").Logf("cleaning %d files", 3)
+	prog.Get("step3").Cancel()
+
+	pb, err := progresspb.ToProto(prog)
+	require.NoError(t, err)
+	require.Len(t, pb.Steps, 3)
+	require.Equal(t, progresspb.State_STATE_DONE, pb.Steps[0].State)
+	require.Equal(t, progresspb.State_STATE_IN_PROGRESS, pb.Steps[1].State)
+	require.Equal(t, progresspb.State_STATE_CANCELED, pb.Steps[2].State)
+	require.Equal(t, "bar", pb.Steps[1].Data.GetStructValue().Fields["foo"].GetStringValue())
+	require.Equal(t, int64(3), pb.Snapshot.Total)
+	require.Equal(t, len(prog.Events()), len(pb.Events))
+
+	// wire round-trip
+	raw, err := proto.Marshal(pb)
+	require.NoError(t, err)
+	var decoded progresspb.Progress
+	require.NoError(t, proto.Unmarshal(raw, &decoded))
+
+	loaded, err := progresspb.FromProto(&decoded)
+	require.NoError(t, err)
+	require.True(t, prog.CreatedAt.Equal(loaded.CreatedAt))
+	require.Len(t, loaded.Steps, 3)
+	require.Equal(t, "hello", loaded.Get("step1").Description)
+	require.Equal(t, float64(42), loaded.Get("step1").Data)
+	require.Equal(t, map[string]interface{}{"foo": "bar"}, loaded.Get("step2").Data)
+	require.Equal(t, "worker-1", loaded.Get("step2").Actor)
+	require.Equal(t, int64(5), loaded.Get("step2").Units)
+	require.True(t, prog.Get("step2").StartedAt.Equal(*loaded.Get("step2").StartedAt))
+	require.Equal(t, progress.StateCanceled, loaded.Get("step3").State)
+	require.Equal(t, []string{"cleanup"}, loaded.Get("step3").Tags)
+	require.Equal(t, "eu", loaded.Get("step3").Meta("region"))
+	require.Equal(t, "cleaning 3 files", loaded.Get("step3").Logs[0].Message)
+	require.Equal(t, len(prog.Events()), len(loaded.Events()))
+
+	snapshot := progresspb.SnapshotFromProto(decoded.Snapshot)
+	require.Equal(t, progress.StateInProgress, snapshot.State)
+	require.Equal(t, 1, snapshot.Completed)
+	require.Equal(t, 1, snapshot.Canceled)
+
+	// the loaded progress can be resumed
+	loaded.Get("step2").Done()
+	require.Equal(t, progress.StateCanceled, loaded.Snapshot().State)
+}
+
+func TestStates(t *testing.T) {
+	for _, state := range []progress.State{
+		progress.StateNotStarted,
+		progress.StateInProgress,
+		progress.StateDone,
+		progress.StateStopped,
+		progress.StateCanceled,
+		progress.StateInterrupted,
+		progress.StateFailed,
+		progress.StateSkipped,
+	} {
+		pb := progresspb.StateToProto(state)
+		require.NotEqual(t, progresspb.State_STATE_UNSPECIFIED, pb)
+		require.Equal(t, state, progresspb.StateFromProto(pb))
+	}
+	require.Equal(t, progresspb.State_STATE_UNSPECIFIED, progresspb.StateToProto("unknown"))
+}
+
+func TestStepAttempts(t *testing.T) {
+	prog := progress.New()
+	calls := 0
+	prog.AddStep("flaky").SetRetry(progress.RetryPolicy{MaxAttempts: 2}).SetFunc(func(context.Context, *progress.Step) error {
+		calls++
+		if calls == 1 {
+			return errors.New("temporary")
+		}
+		return nil
+	})
+	require.NoError(t, prog.Run(context.Background()))
+
+	pb, err := progresspb.StepToProto(prog.Get("flaky"))
+	require.NoError(t, err)
+	require.Len(t, pb.Attempts, 2)
+	step := progresspb.StepFromProto(pb)
+	require.Equal(t, "temporary", step.Attempts[0].Error)
+	require.True(t, prog.Get("flaky").Attempts[1].DoneAt.Equal(step.Attempts[1].DoneAt))
+}
+
+func TestZeroTimes(t *testing.T) {
+	var prog progress.Progress
+	require.NoError(t, json.Unmarshal([]byte(`{"steps":[{"id":"step1","attempts":[{"error":"temporary"}],`+
+		`"logs":[{"message":"hello"}]}],"events":[{"step_id":"step1","to":"not started"}]}`), &prog))
+
+	pb, err := progresspb.ToProto(&prog)
+	require.NoError(t, err)
+	require.Nil(t, pb.CreatedAt)
+	require.Nil(t, pb.Steps[0].Attempts[0].StartedAt)
+	require.Nil(t, pb.Events[0].At)
+
+	decoded, err := progresspb.FromProto(pb)
+	require.NoError(t, err)
+	require.True(t, decoded.CreatedAt.IsZero())
+	step := decoded.Get("step1")
+	require.True(t, step.Attempts[0].StartedAt.IsZero())
+	require.True(t, step.Attempts[0].DoneAt.IsZero())
+	require.True(t, step.Logs[0].At.IsZero())
+	require.True(t, decoded.Events()[0].At.IsZero())
+}
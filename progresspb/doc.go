@@ -0,0 +1,5 @@
+// Package progresspb provides the protobuf definitions of the 'progress' library
+// and helpers to convert from and to the native types.
+package progresspb // import "moul.io/progress/progresspb"
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative progress.proto
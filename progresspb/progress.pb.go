@@ -0,0 +1,904 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        v5.28.3
+// source: progress.proto
+
+package progresspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// State is the state of a step or of a whole progress.
+type State int32
+
+const (
+	State_STATE_UNSPECIFIED State = 0
+	State_STATE_NOT_STARTED State = 1
+	State_STATE_IN_PROGRESS State = 2
+	State_STATE_DONE        State = 3
+	State_STATE_STOPPED     State = 4
+	State_STATE_CANCELED    State = 5
+	State_STATE_INTERRUPTED State = 6
+	State_STATE_FAILED      State = 7
+	State_STATE_SKIPPED     State = 8
+)
+
+// Enum value maps for State.
+var (
+	State_name = map[int32]string{
+		0: "STATE_UNSPECIFIED",
+		1: "STATE_NOT_STARTED",
+		2: "STATE_IN_PROGRESS",
+		3: "STATE_DONE",
+		4: "STATE_STOPPED",
+		5: "STATE_CANCELED",
+		6: "STATE_INTERRUPTED",
+		7: "STATE_FAILED",
+		8: "STATE_SKIPPED",
+	}
+	State_value = map[string]int32{
+		"STATE_UNSPECIFIED": 0,
+		"STATE_NOT_STARTED": 1,
+		"STATE_IN_PROGRESS": 2,
+		"STATE_DONE":        3,
+		"STATE_STOPPED":     4,
+		"STATE_CANCELED":    5,
+		"STATE_INTERRUPTED": 6,
+		"STATE_FAILED":      7,
+		"STATE_SKIPPED":     8,
+	}
+)
+
+func (x State) Enum() *State {
+	p := new(State)
+	*p = x
+	return p
+}
+
+func (x State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (State) Descriptor() protoreflect.EnumDescriptor {
+	return file_progress_proto_enumTypes[0].Descriptor()
+}
+
+func (State) Type() protoreflect.EnumType {
+	return &file_progress_proto_enumTypes[0]
+}
+
+func (x State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use State.Descriptor instead.
+func (State) EnumDescriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{0}
+}
+
+// Progress is the top-level object of the 'progress' library.
+type Progress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Steps         []*Step                `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Snapshot      *Snapshot              `protobuf:"bytes,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	Events        []*Event               `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Progress) Reset() {
+	*x = Progress{}
+	mi := &file_progress_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Progress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Progress) ProtoMessage() {}
+
+func (x *Progress) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Progress.ProtoReflect.Descriptor instead.
+func (*Progress) Descriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Progress) GetSteps() []*Step {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *Progress) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Progress) GetSnapshot() *Snapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *Progress) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// Step represents a progress step.
+type Step struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	DoneAt        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=done_at,json=doneAt,proto3" json:"done_at,omitempty"`
+	State         State                  `protobuf:"varint,5,opt,name=state,proto3,enum=progress.State" json:"state,omitempty"`
+	Data          *structpb.Value        `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+	Progress      float64                `protobuf:"fixed64,7,opt,name=progress,proto3" json:"progress,omitempty"`
+	Actor         string                 `protobuf:"bytes,8,opt,name=actor,proto3" json:"actor,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,9,opt,name=duration,proto3" json:"duration,omitempty"`
+	Units         int64                  `protobuf:"varint,10,opt,name=units,proto3" json:"units,omitempty"`
+	TotalUnits    int64                  `protobuf:"varint,11,opt,name=total_units,json=totalUnits,proto3" json:"total_units,omitempty"`
+	Error         string                 `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
+	Attempts      []*Attempt             `protobuf:"bytes,13,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	SkipReason    string                 `protobuf:"bytes,14,opt,name=skip_reason,json=skipReason,proto3" json:"skip_reason,omitempty"`
+	Group         string                 `protobuf:"bytes,15,opt,name=group,proto3" json:"group,omitempty"`
+	Tags          []string               `protobuf:"bytes,16,rep,name=tags,proto3" json:"tags,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,17,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Logs          []*LogLine             `protobuf:"bytes,18,rep,name=logs,proto3" json:"logs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Step) Reset() {
+	*x = Step{}
+	mi := &file_progress_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Step) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Step) ProtoMessage() {}
+
+func (x *Step) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Step.ProtoReflect.Descriptor instead.
+func (*Step) Descriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Step) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Step) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Step) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Step) GetDoneAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DoneAt
+	}
+	return nil
+}
+
+func (x *Step) GetState() State {
+	if x != nil {
+		return x.State
+	}
+	return State_STATE_UNSPECIFIED
+}
+
+func (x *Step) GetData() *structpb.Value {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Step) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *Step) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *Step) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *Step) GetUnits() int64 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *Step) GetTotalUnits() int64 {
+	if x != nil {
+		return x.TotalUnits
+	}
+	return 0
+}
+
+func (x *Step) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Step) GetAttempts() []*Attempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+func (x *Step) GetSkipReason() string {
+	if x != nil {
+		return x.SkipReason
+	}
+	return ""
+}
+
+func (x *Step) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *Step) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Step) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Step) GetLogs() []*LogLine {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+// Attempt is an execution of a step function.
+type Attempt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	DoneAt        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=done_at,json=doneAt,proto3" json:"done_at,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attempt) Reset() {
+	*x = Attempt{}
+	mi := &file_progress_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attempt) ProtoMessage() {}
+
+func (x *Attempt) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attempt.ProtoReflect.Descriptor instead.
+func (*Attempt) Descriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Attempt) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Attempt) GetDoneAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DoneAt
+	}
+	return nil
+}
+
+func (x *Attempt) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// LogLine is a timestamped message logged by a step.
+type LogLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=at,proto3" json:"at,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogLine) Reset() {
+	*x = LogLine{}
+	mi := &file_progress_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLine) ProtoMessage() {}
+
+func (x *LogLine) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLine.ProtoReflect.Descriptor instead.
+func (*LogLine) Descriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LogLine) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *LogLine) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Snapshot represents info and stats about a progress at a given time.
+type Snapshot struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	State              State                  `protobuf:"varint,1,opt,name=state,proto3,enum=progress.State" json:"state,omitempty"`
+	Doing              string                 `protobuf:"bytes,2,opt,name=doing,proto3" json:"doing,omitempty"`
+	NotStarted         int64                  `protobuf:"varint,3,opt,name=not_started,json=notStarted,proto3" json:"not_started,omitempty"`
+	InProgress         int64                  `protobuf:"varint,4,opt,name=in_progress,json=inProgress,proto3" json:"in_progress,omitempty"`
+	Completed          int64                  `protobuf:"varint,5,opt,name=completed,proto3" json:"completed,omitempty"`
+	Canceled           int64                  `protobuf:"varint,6,opt,name=canceled,proto3" json:"canceled,omitempty"`
+	Total              int64                  `protobuf:"varint,7,opt,name=total,proto3" json:"total,omitempty"`
+	Progress           float64                `protobuf:"fixed64,8,opt,name=progress,proto3" json:"progress,omitempty"`
+	TotalDuration      *durationpb.Duration   `protobuf:"bytes,9,opt,name=total_duration,json=totalDuration,proto3" json:"total_duration,omitempty"`
+	StepDuration       *durationpb.Duration   `protobuf:"bytes,10,opt,name=step_duration,json=stepDuration,proto3" json:"step_duration,omitempty"`
+	CompletionEstimate *durationpb.Duration   `protobuf:"bytes,11,opt,name=completion_estimate,json=completionEstimate,proto3" json:"completion_estimate,omitempty"`
+	DoneAt             *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=done_at,json=doneAt,proto3" json:"done_at,omitempty"`
+	StartedAt          *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	Interrupted        int64                  `protobuf:"varint,14,opt,name=interrupted,proto3" json:"interrupted,omitempty"`
+	Failed             int64                  `protobuf:"varint,15,opt,name=failed,proto3" json:"failed,omitempty"`
+	Skipped            int64                  `protobuf:"varint,16,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Indeterminate      bool                   `protobuf:"varint,17,opt,name=indeterminate,proto3" json:"indeterminate,omitempty"`
+	Stale              bool                   `protobuf:"varint,18,opt,name=stale,proto3" json:"stale,omitempty"`
+	StaleSteps         []string               `protobuf:"bytes,19,rep,name=stale_steps,json=staleSteps,proto3" json:"stale_steps,omitempty"`
+	Outcome            string                 `protobuf:"bytes,20,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	mi := &file_progress_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Snapshot) GetState() State {
+	if x != nil {
+		return x.State
+	}
+	return State_STATE_UNSPECIFIED
+}
+
+func (x *Snapshot) GetDoing() string {
+	if x != nil {
+		return x.Doing
+	}
+	return ""
+}
+
+func (x *Snapshot) GetNotStarted() int64 {
+	if x != nil {
+		return x.NotStarted
+	}
+	return 0
+}
+
+func (x *Snapshot) GetInProgress() int64 {
+	if x != nil {
+		return x.InProgress
+	}
+	return 0
+}
+
+func (x *Snapshot) GetCompleted() int64 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *Snapshot) GetCanceled() int64 {
+	if x != nil {
+		return x.Canceled
+	}
+	return 0
+}
+
+func (x *Snapshot) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Snapshot) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *Snapshot) GetTotalDuration() *durationpb.Duration {
+	if x != nil {
+		return x.TotalDuration
+	}
+	return nil
+}
+
+func (x *Snapshot) GetStepDuration() *durationpb.Duration {
+	if x != nil {
+		return x.StepDuration
+	}
+	return nil
+}
+
+func (x *Snapshot) GetCompletionEstimate() *durationpb.Duration {
+	if x != nil {
+		return x.CompletionEstimate
+	}
+	return nil
+}
+
+func (x *Snapshot) GetDoneAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DoneAt
+	}
+	return nil
+}
+
+func (x *Snapshot) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Snapshot) GetInterrupted() int64 {
+	if x != nil {
+		return x.Interrupted
+	}
+	return 0
+}
+
+func (x *Snapshot) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *Snapshot) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *Snapshot) GetIndeterminate() bool {
+	if x != nil {
+		return x.Indeterminate
+	}
+	return false
+}
+
+func (x *Snapshot) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *Snapshot) GetStaleSteps() []string {
+	if x != nil {
+		return x.StaleSteps
+	}
+	return nil
+}
+
+func (x *Snapshot) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+// Event represents a step state transition recorded in the progress event log.
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StepId        string                 `protobuf:"bytes,1,opt,name=step_id,json=stepId,proto3" json:"step_id,omitempty"`
+	From          State                  `protobuf:"varint,2,opt,name=from,proto3,enum=progress.State" json:"from,omitempty"`
+	To            State                  `protobuf:"varint,3,opt,name=to,proto3,enum=progress.State" json:"to,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=at,proto3" json:"at,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_progress_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_progress_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_progress_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Event) GetStepId() string {
+	if x != nil {
+		return x.StepId
+	}
+	return ""
+}
+
+func (x *Event) GetFrom() State {
+	if x != nil {
+		return x.From
+	}
+	return State_STATE_UNSPECIFIED
+}
+
+func (x *Event) GetTo() State {
+	if x != nil {
+		return x.To
+	}
+	return State_STATE_UNSPECIFIED
+}
+
+func (x *Event) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *Event) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+var File_progress_proto protoreflect.FileDescriptor
+
+const file_progress_proto_rawDesc = "" +
+	"\n" +
+	"\x0eprogress.proto\x12\bprogress\x1a\x1egoogle/protobuf/duration.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc4\x01\n" +
+	"\bProgress\x12$\n" +
+	"\x05steps\x18\x01 \x03(\v2\x0e.progress.StepR\x05steps\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12.\n" +
+	"\bsnapshot\x18\x03 \x01(\v2\x12.progress.SnapshotR\bsnapshot\x12'\n" +
+	"\x06events\x18\x04 \x03(\v2\x0f.progress.EventR\x06events\"\xc9\x05\n" +
+	"\x04Step\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x123\n" +
+	"\adone_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x06doneAt\x12%\n" +
+	"\x05state\x18\x05 \x01(\x0e2\x0f.progress.StateR\x05state\x12*\n" +
+	"\x04data\x18\x06 \x01(\v2\x16.google.protobuf.ValueR\x04data\x12\x1a\n" +
+	"\bprogress\x18\a \x01(\x01R\bprogress\x12\x14\n" +
+	"\x05actor\x18\b \x01(\tR\x05actor\x125\n" +
+	"\bduration\x18\t \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x14\n" +
+	"\x05units\x18\n" +
+	" \x01(\x03R\x05units\x12\x1f\n" +
+	"\vtotal_units\x18\v \x01(\x03R\n" +
+	"totalUnits\x12\x14\n" +
+	"\x05error\x18\f \x01(\tR\x05error\x12-\n" +
+	"\battempts\x18\r \x03(\v2\x11.progress.AttemptR\battempts\x12\x1f\n" +
+	"\vskip_reason\x18\x0e \x01(\tR\n" +
+	"skipReason\x12\x14\n" +
+	"\x05group\x18\x0f \x01(\tR\x05group\x12\x12\n" +
+	"\x04tags\x18\x10 \x03(\tR\x04tags\x128\n" +
+	"\bmetadata\x18\x11 \x03(\v2\x1c.progress.Step.MetadataEntryR\bmetadata\x12%\n" +
+	"\x04logs\x18\x12 \x03(\v2\x11.progress.LogLineR\x04logs\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8f\x01\n" +
+	"\aAttempt\x129\n" +
+	"\n" +
+	"started_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x123\n" +
+	"\adone_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x06doneAt\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"O\n" +
+	"\aLogLine\x12*\n" +
+	"\x02at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xfe\x05\n" +
+	"\bSnapshot\x12%\n" +
+	"\x05state\x18\x01 \x01(\x0e2\x0f.progress.StateR\x05state\x12\x14\n" +
+	"\x05doing\x18\x02 \x01(\tR\x05doing\x12\x1f\n" +
+	"\vnot_started\x18\x03 \x01(\x03R\n" +
+	"notStarted\x12\x1f\n" +
+	"\vin_progress\x18\x04 \x01(\x03R\n" +
+	"inProgress\x12\x1c\n" +
+	"\tcompleted\x18\x05 \x01(\x03R\tcompleted\x12\x1a\n" +
+	"\bcanceled\x18\x06 \x01(\x03R\bcanceled\x12\x14\n" +
+	"\x05total\x18\a \x01(\x03R\x05total\x12\x1a\n" +
+	"\bprogress\x18\b \x01(\x01R\bprogress\x12@\n" +
+	"\x0etotal_duration\x18\t \x01(\v2\x19.google.protobuf.DurationR\rtotalDuration\x12>\n" +
+	"\rstep_duration\x18\n" +
+	" \x01(\v2\x19.google.protobuf.DurationR\fstepDuration\x12J\n" +
+	"\x13completion_estimate\x18\v \x01(\v2\x19.google.protobuf.DurationR\x12completionEstimate\x123\n" +
+	"\adone_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x06doneAt\x129\n" +
+	"\n" +
+	"started_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12 \n" +
+	"\vinterrupted\x18\x0e \x01(\x03R\vinterrupted\x12\x16\n" +
+	"\x06failed\x18\x0f \x01(\x03R\x06failed\x12\x18\n" +
+	"\askipped\x18\x10 \x01(\x03R\askipped\x12$\n" +
+	"\rindeterminate\x18\x11 \x01(\bR\rindeterminate\x12\x14\n" +
+	"\x05stale\x18\x12 \x01(\bR\x05stale\x12\x1f\n" +
+	"\vstale_steps\x18\x13 \x03(\tR\n" +
+	"staleSteps\x12\x18\n" +
+	"\aoutcome\x18\x14 \x01(\tR\aoutcome\"\xa8\x01\n" +
+	"\x05Event\x12\x17\n" +
+	"\astep_id\x18\x01 \x01(\tR\x06stepId\x12#\n" +
+	"\x04from\x18\x02 \x01(\x0e2\x0f.progress.StateR\x04from\x12\x1f\n" +
+	"\x02to\x18\x03 \x01(\x0e2\x0f.progress.StateR\x02to\x12*\n" +
+	"\x02at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor*\xbf\x01\n" +
+	"\x05State\x12\x15\n" +
+	"\x11STATE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11STATE_NOT_STARTED\x10\x01\x12\x15\n" +
+	"\x11STATE_IN_PROGRESS\x10\x02\x12\x0e\n" +
+	"\n" +
+	"STATE_DONE\x10\x03\x12\x11\n" +
+	"\rSTATE_STOPPED\x10\x04\x12\x12\n" +
+	"\x0eSTATE_CANCELED\x10\x05\x12\x15\n" +
+	"\x11STATE_INTERRUPTED\x10\x06\x12\x10\n" +
+	"\fSTATE_FAILED\x10\a\x12\x11\n" +
+	"\rSTATE_SKIPPED\x10\bB\x1dZ\x1bmoul.io/progress/progresspbb\x06proto3"
+
+var (
+	file_progress_proto_rawDescOnce sync.Once
+	file_progress_proto_rawDescData []byte
+)
+
+func file_progress_proto_rawDescGZIP() []byte {
+	file_progress_proto_rawDescOnce.Do(func() {
+		file_progress_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_progress_proto_rawDesc), len(file_progress_proto_rawDesc)))
+	})
+	return file_progress_proto_rawDescData
+}
+
+var file_progress_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_progress_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_progress_proto_goTypes = []any{
+	(State)(0),                    // 0: progress.State
+	(*Progress)(nil),              // 1: progress.Progress
+	(*Step)(nil),                  // 2: progress.Step
+	(*Attempt)(nil),               // 3: progress.Attempt
+	(*LogLine)(nil),               // 4: progress.LogLine
+	(*Snapshot)(nil),              // 5: progress.Snapshot
+	(*Event)(nil),                 // 6: progress.Event
+	nil,                           // 7: progress.Step.MetadataEntry
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+	(*structpb.Value)(nil),        // 9: google.protobuf.Value
+	(*durationpb.Duration)(nil),   // 10: google.protobuf.Duration
+}
+var file_progress_proto_depIdxs = []int32{
+	2,  // 0: progress.Progress.steps:type_name -> progress.Step
+	8,  // 1: progress.Progress.created_at:type_name -> google.protobuf.Timestamp
+	5,  // 2: progress.Progress.snapshot:type_name -> progress.Snapshot
+	6,  // 3: progress.Progress.events:type_name -> progress.Event
+	8,  // 4: progress.Step.started_at:type_name -> google.protobuf.Timestamp
+	8,  // 5: progress.Step.done_at:type_name -> google.protobuf.Timestamp
+	0,  // 6: progress.Step.state:type_name -> progress.State
+	9,  // 7: progress.Step.data:type_name -> google.protobuf.Value
+	10, // 8: progress.Step.duration:type_name -> google.protobuf.Duration
+	3,  // 9: progress.Step.attempts:type_name -> progress.Attempt
+	7,  // 10: progress.Step.metadata:type_name -> progress.Step.MetadataEntry
+	4,  // 11: progress.Step.logs:type_name -> progress.LogLine
+	8,  // 12: progress.Attempt.started_at:type_name -> google.protobuf.Timestamp
+	8,  // 13: progress.Attempt.done_at:type_name -> google.protobuf.Timestamp
+	8,  // 14: progress.LogLine.at:type_name -> google.protobuf.Timestamp
+	0,  // 15: progress.Snapshot.state:type_name -> progress.State
+	10, // 16: progress.Snapshot.total_duration:type_name -> google.protobuf.Duration
+	10, // 17: progress.Snapshot.step_duration:type_name -> google.protobuf.Duration
+	10, // 18: progress.Snapshot.completion_estimate:type_name -> google.protobuf.Duration
+	8,  // 19: progress.Snapshot.done_at:type_name -> google.protobuf.Timestamp
+	8,  // 20: progress.Snapshot.started_at:type_name -> google.protobuf.Timestamp
+	0,  // 21: progress.Event.from:type_name -> progress.State
+	0,  // 22: progress.Event.to:type_name -> progress.State
+	8,  // 23: progress.Event.at:type_name -> google.protobuf.Timestamp
+	24, // [24:24] is the sub-list for method output_type
+	24, // [24:24] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
+}
+
+func init() { file_progress_proto_init() }
+func file_progress_proto_init() {
+	if File_progress_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_progress_proto_rawDesc), len(file_progress_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_progress_proto_goTypes,
+		DependencyIndexes: file_progress_proto_depIdxs,
+		EnumInfos:         file_progress_proto_enumTypes,
+		MessageInfos:      file_progress_proto_msgTypes,
+	}.Build()
+	File_progress_proto = out.File
+	file_progress_proto_goTypes = nil
+	file_progress_proto_depIdxs = nil
+}
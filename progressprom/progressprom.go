@@ -0,0 +1,90 @@
+// Package progressprom provides a Prometheus collector exposing the metrics of a Progress.
+package progressprom // import "moul.io/progress/progressprom"
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"moul.io/progress"
+)
+
+const subsystem = "progress"
+
+// Collector implements prometheus.Collector for a Progress.
+//
+// The following metrics are exposed, prefixed by the namespace:
+//
+//	progress_ratio                  completion between 0 and 1.
+//	progress_steps{state}           number of steps per state.
+//	progress_step_duration_seconds  duration of each started step, labeled by step id.
+//	progress_duration_seconds       total duration of the Progress.
+type Collector struct {
+	prog          *progress.Progress
+	ratio         *prometheus.Desc
+	steps         *prometheus.Desc
+	stepDuration  *prometheus.Desc
+	totalDuration *prometheus.Desc
+}
+
+var _ prometheus.Collector = (*Collector)(nil)
+
+// NewCollector returns a Collector exposing 'prog' metrics under 'namespace', which may be empty.
+// 'constLabels' are added to every metric, and may be used to distinguish several progresses.
+func NewCollector(prog *progress.Progress, namespace string, constLabels prometheus.Labels) *Collector {
+	return &Collector{
+		prog: prog,
+		ratio: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "ratio"),
+			"Completion of the progress, between 0 and 1.",
+			nil, constLabels,
+		),
+		steps: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "steps"),
+			"Number of steps per state.",
+			[]string{"state"}, constLabels,
+		),
+		stepDuration: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "step_duration_seconds"),
+			"Duration of each started step.",
+			[]string{"step"}, constLabels,
+		),
+		totalDuration: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "duration_seconds"),
+			"Total duration of the progress.",
+			nil, constLabels,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.ratio
+	ch <- c.steps
+	ch <- c.stepDuration
+	ch <- c.totalDuration
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	snapshot := c.prog.Snapshot()
+	ch <- prometheus.MustNewConstMetric(c.ratio, prometheus.GaugeValue, snapshot.Progress)
+	ch <- prometheus.MustNewConstMetric(c.totalDuration, prometheus.GaugeValue, snapshot.TotalDuration.Seconds())
+
+	counts := map[progress.State]int{
+		progress.StateNotStarted:  snapshot.NotStarted,
+		progress.StateInProgress:  snapshot.InProgress,
+		progress.StateDone:        snapshot.Completed,
+		progress.StateCanceled:    snapshot.Canceled,
+		progress.StateInterrupted: snapshot.Interrupted,
+		progress.StateFailed:      snapshot.Failed,
+		progress.StateSkipped:     snapshot.Skipped,
+	}
+	for state, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.steps, prometheus.GaugeValue, float64(count), string(state))
+	}
+
+	for step := range c.prog.AllSteps() {
+		if step.StartedAt == nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.stepDuration, prometheus.GaugeValue, step.Duration().Seconds(), step.ID)
+	}
+}
@@ -0,0 +1,44 @@
+package progressprom_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progressprom"
+)
+
+func TestCollector(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Done()
+	prog.AddStep("step2").Start()
+	prog.AddStep("step3")
+	prog.AddStep("step4")
+
+	collector := progressprom.NewCollector(prog, "myjob", prometheus.Labels{"job": "test"})
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(collector))
+
+	expected := `
+# HELP myjob_progress_ratio Completion of the progress, between 0 and 1.
+# TYPE myjob_progress_ratio gauge
+myjob_progress_ratio{job="test"} 0.375
+# HELP myjob_progress_steps Number of steps per state.
+# TYPE myjob_progress_steps gauge
+myjob_progress_steps{job="test",state="canceled"} 0
+myjob_progress_steps{job="test",state="done"} 1
+myjob_progress_steps{job="test",state="failed"} 0
+myjob_progress_steps{job="test",state="in progress"} 1
+myjob_progress_steps{job="test",state="interrupted"} 0
+myjob_progress_steps{job="test",state="not started"} 2
+myjob_progress_steps{job="test",state="skipped"} 0
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "myjob_progress_ratio", "myjob_progress_steps"))
+
+	// only started steps have a duration
+	require.Equal(t, 2, testutil.CollectAndCount(collector, "myjob_progress_step_duration_seconds"))
+	require.Equal(t, 1, testutil.CollectAndCount(collector, "myjob_progress_duration_seconds"))
+}
@@ -0,0 +1,72 @@
+// Package progresspubsub provides a publisher pushing step transitions to a gocloud.dev/pubsub topic.
+//
+// Any gocloud.dev driver can be used, including NATS (gocloud.dev/pubsub/natspubsub),
+// Kafka, Google Cloud Pub/Sub, or Amazon SNS.
+package progresspubsub // import "moul.io/progress/progresspubsub"
+
+import (
+	"context"
+	"encoding/json"
+
+	"gocloud.dev/pubsub"
+	"moul.io/progress"
+)
+
+// Message is the JSON body of each published message.
+// Snapshot is computed when the message is sent, and may already include later transitions.
+type Message struct {
+	ProgressID string            `json:"progress_id,omitempty"`
+	Event      progress.Event    `json:"event"`
+	Step       *progress.Step    `json:"step"`
+	Snapshot   progress.Snapshot `json:"snapshot"`
+}
+
+// Metadata keys set on each published message, allowing subscribers to filter without decoding the body.
+const (
+	MetadataProgressID = "progress_id"
+	MetadataStepID     = "step_id"
+	MetadataState      = "state"
+)
+
+// Publish sends a Message to 'topic' for each step transition of 'prog', identified by 'progressID',
+// until the Progress becomes terminal or the returned stop function is called.
+// The stop function returns the first error encountered while sending.
+func Publish(ctx context.Context, prog *progress.Progress, topic *pubsub.Topic, progressID string) (stop func() error) {
+	subscriber := prog.SubscribeEvents()
+	done := make(chan struct{})
+	var sendErr error
+	go func() {
+		defer close(done)
+		for event := range subscriber {
+			msg := Message{
+				ProgressID: progressID,
+				Event:      event.Event,
+				Step:       event.Step,
+				Snapshot:   prog.Snapshot(),
+			}
+			if err := send(ctx, topic, msg); err != nil && sendErr == nil {
+				sendErr = err
+			}
+		}
+	}()
+	return func() error {
+		prog.UnsubscribeEvents(subscriber)
+		<-done
+		return sendErr
+	}
+}
+
+func send(ctx context.Context, topic *pubsub.Topic, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return topic.Send(ctx, &pubsub.Message{
+		Body: body,
+		Metadata: map[string]string{
+			MetadataProgressID: msg.ProgressID,
+			MetadataStepID:     msg.Event.StepID,
+			MetadataState:      string(msg.Event.To),
+		},
+	})
+}
@@ -0,0 +1,65 @@
+package progresspubsub_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/pubsub"
+	"gocloud.dev/pubsub/mempubsub"
+	"moul.io/progress"
+	"moul.io/progress/progresspubsub"
+	"moul.io/progress/progresstest"
+)
+
+func TestPublish(t *testing.T) {
+	ctx := context.Background()
+	topic := mempubsub.NewTopic()
+	defer topic.Shutdown(ctx)
+	sub := mempubsub.NewSubscription(topic, time.Minute)
+	defer sub.Shutdown(ctx)
+
+	startedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := progresstest.NewClock(startedAt)
+	prog := progress.New(progress.WithClock(clock))
+	prog.AddStep("step1")
+	stop := progresspubsub.Publish(ctx, prog, topic, "job1")
+	prog.Get("step1").Start()
+	clock.Advance(time.Minute)
+	prog.Get("step1").Done()
+	require.NoError(t, stop())
+
+	receive := func() (*pubsub.Message, progresspubsub.Message) {
+		msg, err := sub.Receive(ctx)
+		require.NoError(t, err)
+		msg.Ack()
+		var decoded progresspubsub.Message
+		require.NoError(t, json.Unmarshal(msg.Body, &decoded))
+		return msg, decoded
+	}
+
+	// the delivery order is not guaranteed
+	received := map[progress.State]*pubsub.Message{}
+	decoded := map[progress.State]progresspubsub.Message{}
+	for i := 0; i < 2; i++ {
+		msg, message := receive()
+		received[message.Event.To] = msg
+		decoded[message.Event.To] = message
+	}
+
+	msg := received[progress.StateInProgress]
+	require.NotNil(t, msg)
+	require.Equal(t, "job1", msg.Metadata[progresspubsub.MetadataProgressID])
+	require.Equal(t, "step1", msg.Metadata[progresspubsub.MetadataStepID])
+	require.Equal(t, "in progress", msg.Metadata[progresspubsub.MetadataState])
+	require.Equal(t, progress.StateNotStarted, decoded[progress.StateInProgress].Event.From)
+	require.True(t, startedAt.Equal(decoded[progress.StateInProgress].Event.At))
+
+	done := decoded[progress.StateDone]
+	require.Equal(t, progress.StateInProgress, done.Event.From)
+	require.True(t, startedAt.Add(time.Minute).Equal(done.Event.At))
+	require.Equal(t, "step1", done.Step.ID)
+	require.Equal(t, progress.StateDone, done.Snapshot.State)
+}
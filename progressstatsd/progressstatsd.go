@@ -0,0 +1,96 @@
+// Package progressstatsd provides an emitter sending Progress metrics to StatsD or DogStatsD.
+package progressstatsd // import "moul.io/progress/progressstatsd"
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"moul.io/progress"
+)
+
+// Emitter writes StatsD metrics on each step transition.
+//
+// The following metrics are sent, prefixed by the configured prefix:
+//
+//	steps.started    counter, when a step starts.
+//	steps.completed  counter, when a step is done.
+//	steps.canceled   counter, when a step is canceled.
+//	steps.failed     counter, when a step fails.
+//	steps.skipped    counter, when a step is skipped.
+//	step.duration    timing, when a step is done.
+//	duration         timing, when the Progress becomes terminal.
+//
+// When tags are configured, the DogStatsD format is used and a "step:<id>" tag is added to step metrics.
+type Emitter struct {
+	w      io.Writer
+	prefix string
+	tags   []string
+	mutex  sync.Mutex
+}
+
+// NewEmitter returns an Emitter writing to 'w', usually a UDP connection created with net.Dial.
+// Each metric is sent with a single Write call.
+func NewEmitter(w io.Writer, prefix string, tags ...string) *Emitter {
+	return &Emitter{w: w, prefix: prefix, tags: tags}
+}
+
+// Attach subscribes to 'prog' and emits metrics until the Progress becomes terminal or the returned
+// stop function is called.
+func (e *Emitter) Attach(prog *progress.Progress) (stop func()) {
+	subscriber := prog.SubscribeEvents()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for event := range subscriber {
+			switch event.To {
+			case progress.StateInProgress:
+				e.send("steps.started", "1|c", event.StepID)
+			case progress.StateDone:
+				e.send("steps.completed", "1|c", event.StepID)
+				e.send("step.duration", timing(event.Step.Duration()), event.StepID)
+			case progress.StateCanceled:
+				e.send("steps.canceled", "1|c", event.StepID)
+			case progress.StateFailed:
+				e.send("steps.failed", "1|c", event.StepID)
+			case progress.StateSkipped:
+				e.send("steps.skipped", "1|c", event.StepID)
+			}
+		}
+		if snapshot := prog.Snapshot(); snapshot.State.IsTerminal() {
+			e.send("duration", timing(snapshot.TotalDuration), "")
+		}
+	}()
+	return func() {
+		prog.UnsubscribeEvents(subscriber)
+		<-done
+	}
+}
+
+func (e *Emitter) send(name, value, stepID string) {
+	var b strings.Builder
+	b.WriteString(e.prefix)
+	b.WriteString(name)
+	b.WriteByte(':')
+	b.WriteString(value)
+	if len(e.tags) > 0 {
+		b.WriteString("|#")
+		b.WriteString(strings.Join(e.tags, ","))
+		if stepID != "" {
+			b.WriteString(",step:")
+			b.WriteString(stepID)
+		}
+	}
+	b.WriteByte('\n')
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	// metrics are best-effort, errors are ignored as with any StatsD client
+	_, _ = io.WriteString(e.w, b.String())
+}
+
+func timing(d time.Duration) string {
+	return fmt.Sprintf("%d|ms", d.Milliseconds())
+}
@@ -0,0 +1,45 @@
+package progressstatsd_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progressstatsd"
+)
+
+func TestEmitter(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1")
+	prog.AddStep("step2")
+
+	var buf bytes.Buffer
+	stop := progressstatsd.NewEmitter(&buf, "myjob.", "env:test").Attach(prog)
+	prog.Get("step1").Start()
+	prog.Get("step1").Done()
+	prog.Get("step2").Cancel()
+	stop()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Equal(t, []string{
+		"myjob.steps.started:1|c|#env:test,step:step1",
+		"myjob.steps.completed:1|c|#env:test,step:step1",
+		"myjob.step.duration:0|ms|#env:test,step:step1",
+		"myjob.steps.canceled:1|c|#env:test,step:step2",
+		"myjob.duration:0|ms|#env:test",
+	}, lines)
+}
+
+func TestEmitter_statsd(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1")
+
+	var buf bytes.Buffer
+	stop := progressstatsd.NewEmitter(&buf, "").Attach(prog)
+	prog.Get("step1").Start()
+	stop()
+
+	require.Equal(t, "steps.started:1|c\n", buf.String())
+}
@@ -0,0 +1,121 @@
+package progresstest
+
+import (
+	"sync"
+	"time"
+
+	"moul.io/progress"
+)
+
+// Clock is a manual progress.Clock, only moving forward when told to.
+// It is safe for concurrent use.
+type Clock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	tickers []*ticker
+}
+
+var _ progress.TickerClock = (*Clock)(nil)
+
+// ticker is a ticker driven by a Clock.
+type ticker struct {
+	ch       chan time.Time
+	stopped  chan struct{}
+	interval time.Duration
+	next     time.Time
+}
+
+// NewClock returns a Clock set to 'now'.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by 'd', see Set.
+func (c *Clock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	c.now = c.now.Add(d)
+	ticks := c.dueTicks()
+	c.mutex.Unlock()
+	sendTicks(ticks)
+}
+
+// Set moves the clock to 'now'.
+// The tickers created with NewTicker tick for each of their intervals elapsed, and Set blocks until each tick
+// is received: when it returns, the receivers are done processing every tick but the last one.
+func (c *Clock) Set(now time.Time) {
+	c.mutex.Lock()
+	c.now = now
+	ticks := c.dueTicks()
+	c.mutex.Unlock()
+	sendTicks(ticks)
+}
+
+// tick is a pending tick of a ticker.
+type tick struct {
+	ticker *ticker
+	at     time.Time
+}
+
+// dueTicks returns the ticks elapsed at the current time, and schedules the next ones.
+// The caller is responsible for holding the lock.
+func (c *Clock) dueTicks() []tick {
+	var ticks []tick
+	for _, ticker := range c.tickers {
+		for !ticker.next.After(c.now) {
+			ticks = append(ticks, tick{ticker: ticker, at: ticker.next})
+			ticker.next = ticker.next.Add(ticker.interval)
+		}
+	}
+	return ticks
+}
+
+// sendTicks sends each tick to its ticker, unless the ticker is stopped.
+func sendTicks(ticks []tick) {
+	for _, tick := range ticks {
+		select {
+		case tick.ticker.ch <- tick.at:
+		case <-tick.ticker.stopped:
+		}
+	}
+}
+
+// NewTicker implements progress.TickerClock.
+// The returned chan receives the time every 'd' the clock is moved forward by, see Set.
+// A non-positive 'd' is not allowed, else it will panic.
+func (c *Clock) NewTicker(d time.Duration) (<-chan time.Time, func()) {
+	if d <= 0 {
+		panic("progresstest: non-positive interval for NewTicker")
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	ticker := &ticker{
+		ch:       make(chan time.Time),
+		stopped:  make(chan struct{}),
+		interval: d,
+		next:     c.now.Add(d),
+	}
+	c.tickers = append(c.tickers, ticker)
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			c.mutex.Lock()
+			defer c.mutex.Unlock()
+			close(ticker.stopped)
+			for idx, other := range c.tickers {
+				if other == ticker {
+					c.tickers = append(c.tickers[:idx], c.tickers[idx+1:]...)
+					break
+				}
+			}
+		})
+	}
+	return ticker.ch, stop
+}
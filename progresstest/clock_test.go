@@ -0,0 +1,57 @@
+package progresstest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progresstest"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := progresstest.NewClock(start)
+	prog := progress.New(progress.WithClock(clock))
+	require.Equal(t, start, prog.CreatedAt)
+
+	step := prog.AddStep("a").Start()
+	clock.Advance(time.Minute)
+	require.Equal(t, time.Minute, step.Duration())
+	require.Equal(t, time.Minute, prog.Snapshot().TotalDuration)
+
+	clock.Advance(time.Minute)
+	step.Done()
+	require.Equal(t, start.Add(2*time.Minute), *step.DoneAt)
+	clock.Set(start.Add(time.Hour))
+	require.Equal(t, 2*time.Minute, step.Duration())
+}
+
+func TestClock_ticker(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := progresstest.NewClock(start)
+	ticks, stop := clock.NewTicker(time.Second)
+
+	received := make(chan time.Time, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for idx := 0; idx < 3; idx++ {
+			received <- <-ticks
+		}
+	}()
+
+	clock.Advance(500 * time.Millisecond)
+	require.Empty(t, received)
+	clock.Advance(2 * time.Second)
+	require.Equal(t, start.Add(time.Second), <-received)
+	require.Equal(t, start.Add(2*time.Second), <-received)
+	clock.Set(start.Add(3 * time.Second))
+	<-done
+	require.Equal(t, start.Add(3*time.Second), <-received)
+
+	// the ticks of a stopped ticker are dropped
+	stop()
+	stop()
+	clock.Advance(time.Minute)
+}
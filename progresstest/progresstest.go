@@ -0,0 +1,156 @@
+// Package progresstest provides helpers to test code reporting its progress with a Progress.
+package progresstest // import "moul.io/progress/progresstest"
+
+import (
+	"encoding/json"
+	"strings"
+
+	"moul.io/progress"
+)
+
+// TestingT is the subset of testing.TB used by the helpers.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	FailNow()
+}
+
+// RequireStepDone fails the test immediately if the step matching 'id' is not done.
+func RequireStepDone(t TestingT, prog *progress.Progress, id string) {
+	t.Helper()
+	RequireStepState(t, prog, id, progress.StateDone)
+}
+
+// RequireStepState fails the test immediately if the step matching 'id' is not in 'state'.
+func RequireStepState(t TestingT, prog *progress.Progress, id string, state progress.State) {
+	t.Helper()
+	step := findStep(t, prog, id)
+	if step != nil && step.State != state {
+		t.Errorf("step %q is %q, expected %q", id, step.State, state)
+		t.FailNow()
+	}
+}
+
+// RequireOrder fails the test immediately unless each step of 'ids' started after the previous one
+// terminated, based on the event log of the Progress.
+func RequireOrder(t TestingT, prog *progress.Progress, ids ...string) {
+	t.Helper()
+	events := prog.Events()
+	index := func(id string, match func(progress.State) bool) int {
+		for idx, event := range events {
+			if event.StepID == id && match(event.To) {
+				return idx
+			}
+		}
+		return -1
+	}
+	isStarted := func(state progress.State) bool { return state == progress.StateInProgress }
+	for i := 1; i < len(ids); i++ {
+		previous, current := ids[i-1], ids[i]
+		terminated := index(previous, progress.State.IsTerminal)
+		started := index(current, isStarted)
+		switch {
+		case terminated < 0:
+			t.Errorf("step %q never terminated", previous)
+		case started < 0:
+			t.Errorf("step %q never started", current)
+		case started < terminated:
+			t.Errorf("step %q started before step %q terminated", current, previous)
+		default:
+			continue
+		}
+		t.FailNow()
+	}
+}
+
+// Snapshot returns the snapshot of 'prog' without timestamps and durations, so it can be compared
+// to an expected value.
+func Snapshot(prog *progress.Progress) progress.Snapshot {
+	return normalize(prog.Snapshot())
+}
+
+// normalize removes the timestamps and durations of 'snapshot' and its nested snapshots.
+func normalize(snapshot progress.Snapshot) progress.Snapshot {
+	snapshot.StartedAt = nil
+	snapshot.DoneAt = nil
+	snapshot.TotalDuration = 0
+	snapshot.StepDuration = 0
+	snapshot.CompletionEstimate = 0
+	for tag, nested := range snapshot.ByTag {
+		snapshot.ByTag[tag] = normalize(nested)
+	}
+	for idx, group := range snapshot.Groups {
+		snapshot.Groups[idx].Snapshot = normalize(group.Snapshot)
+	}
+	for id, nested := range snapshot.Children {
+		snapshot.Children[id] = normalize(nested)
+	}
+	return snapshot
+}
+
+// timeKeys are the JSON keys stripped by JSON.
+var timeKeys = map[string]bool{
+	"created_at":                true,
+	"started_at":                true,
+	"done_at":                   true,
+	"at":                        true,
+	"duration":                  true,
+	"duration_human":            true,
+	"total_duration":            true,
+	"total_duration_human":      true,
+	"step_duration":             true,
+	"step_duration_human":       true,
+	"completion_estimate":       true,
+	"completion_estimate_human": true,
+}
+
+// JSON returns the indented JSON representation of 'prog' without timestamps and durations,
+// suitable for golden files.
+func JSON(prog *progress.Progress) (string, error) {
+	raw, err := json.Marshal(prog)
+	if err != nil {
+		return "", err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return "", err
+	}
+	stripTimes(generic)
+	var b strings.Builder
+	encoder := json.NewEncoder(&b)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(generic); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func stripTimes(value interface{}) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, child := range typed {
+			if timeKeys[key] {
+				delete(typed, key)
+				continue
+			}
+			stripTimes(child)
+		}
+	case []interface{}:
+		for _, child := range typed {
+			stripTimes(child)
+		}
+	}
+}
+
+// findStep returns a detached copy of the step matching 'id', or fails the test immediately.
+func findStep(t TestingT, prog *progress.Progress, id string) *progress.Step {
+	t.Helper()
+	for step := range prog.AllSteps() {
+		if step.ID == id {
+			return step
+		}
+	}
+	t.Errorf("%v: %q", progress.ErrStepNotFound, id)
+	t.FailNow()
+	return nil
+}
@@ -0,0 +1,90 @@
+package progresstest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progresstest"
+)
+
+// fakeT records failures instead of stopping the test.
+type fakeT struct {
+	errors []string
+	failed bool
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeT) FailNow() { t.failed = true }
+
+func TestRequireStepDone(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("a").Done()
+	prog.AddStep("b").Start()
+	progresstest.RequireStepDone(t, prog, "a")
+	progresstest.RequireStepState(t, prog, "b", progress.StateInProgress)
+
+	fake := &fakeT{}
+	progresstest.RequireStepDone(fake, prog, "b")
+	require.True(t, fake.failed)
+	require.Equal(t, []string{`step "b" is "in progress", expected "done"`}, fake.errors)
+
+	fake = &fakeT{}
+	progresstest.RequireStepDone(fake, prog, "unknown")
+	require.True(t, fake.failed)
+}
+
+func TestRequireOrder(t *testing.T) {
+	prog := progress.New()
+	for _, id := range []string{"a", "b", "c"} {
+		prog.AddStep(id).SetFunc(func(context.Context, *progress.Step) error { return nil })
+	}
+	require.NoError(t, prog.Run(context.Background()))
+	progresstest.RequireOrder(t, prog, "a", "b", "c")
+
+	fake := &fakeT{}
+	progresstest.RequireOrder(fake, prog, "b", "a")
+	require.True(t, fake.failed)
+	require.Equal(t, []string{`step "a" started before step "b" terminated`}, fake.errors)
+
+	prog.AddStep("d")
+	fake = &fakeT{}
+	progresstest.RequireOrder(fake, prog, "c", "d")
+	require.Equal(t, []string{`step "d" never started`}, fake.errors)
+}
+
+func TestSnapshot(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("a").Done()
+	prog.AddStep("b").Start()
+	snapshot := progresstest.Snapshot(prog)
+	require.Nil(t, snapshot.StartedAt)
+	require.Nil(t, snapshot.DoneAt)
+	require.Zero(t, snapshot.TotalDuration)
+	require.Equal(t, 1, snapshot.Completed)
+	require.Equal(t, 2, snapshot.Total)
+}
+
+func TestJSON(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("a").SetDescription("hello").Done()
+	prog.AddStep("b")
+	got, err := progresstest.JSON(prog)
+	require.NoError(t, err)
+	require.NotContains(t, got, "started_at")
+	require.NotContains(t, got, "done_at")
+	require.NotContains(t, got, "duration")
+
+	// the output does not depend on the time
+	again, err := progresstest.JSON(prog)
+	require.NoError(t, err)
+	require.Equal(t, got, again)
+	require.Contains(t, got, `"description": "hello"`)
+}
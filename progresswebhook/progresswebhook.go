@@ -0,0 +1,209 @@
+// Package progresswebhook provides a notifier POSTing step transitions to a webhook.
+package progresswebhook // import "moul.io/progress/progresswebhook"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"moul.io/progress"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultBackoff    = time.Second
+)
+
+// Payload is the JSON body POSTed for each matching transition.
+// Snapshot is computed when the payload is sent, and may already include later transitions.
+type Payload struct {
+	Step     *progress.Step    `json:"step"`
+	From     progress.State    `json:"from,omitempty"`
+	To       progress.State    `json:"to"`
+	Snapshot progress.Snapshot `json:"snapshot"`
+}
+
+// Notifier POSTs a Payload to URL for each step transition matching all the Filters.
+type Notifier struct {
+	// URL is the webhook endpoint.
+	URL string
+	// Filters select the notified transitions, based on the step in its new state;
+	// see progress.FilterStates.
+	Filters []progress.SubscribeFilter
+	// Client is used to send requests, http.DefaultClient if nil.
+	Client *http.Client
+	// MaxRetries is the number of retries after a failed delivery, 3 if zero; use a negative value to disable retries.
+	// The client errors (4xx statuses) are not retried, except 429 Too Many Requests.
+	MaxRetries int
+	// Backoff is the delay before the first retry, 1s if zero; it doubles on each retry.
+	Backoff time.Duration
+}
+
+// Attach subscribes to 'prog' and notifies transitions, in order, until the Progress becomes terminal,
+// 'ctx' is done, or the returned stop function is called.
+// The transitions are queued and delivered from a dedicated goroutine, so a slow endpoint never blocks the
+// Progress. The stop function waits for the queued deliveries, unless 'ctx' is done, and returns the first
+// delivery error.
+func (n *Notifier) Attach(ctx context.Context, prog *progress.Progress) (stop func() error) {
+	subscriber := prog.SubscribeEvents()
+	queue := newQueue()
+	received := make(chan struct{})
+	go func() {
+		defer close(received)
+		defer queue.close()
+		for event := range subscriber {
+			if !n.match(event.Step) || ctx.Err() != nil {
+				continue
+			}
+			queue.push(Payload{Step: event.Step, From: event.From, To: event.To})
+		}
+	}()
+
+	delivered := make(chan struct{})
+	var notifyErr error
+	go func() {
+		defer close(delivered)
+		for {
+			payload, ok := queue.pop(ctx)
+			if !ok {
+				return
+			}
+			payload.Snapshot = prog.Snapshot()
+			if err := n.deliver(ctx, payload); err != nil && notifyErr == nil {
+				notifyErr = err
+			}
+		}
+	}()
+	return func() error {
+		prog.UnsubscribeEvents(subscriber)
+		<-received
+		<-delivered
+		return notifyErr
+	}
+}
+
+// queue is an unbounded FIFO of payloads waiting for delivery.
+type queue struct {
+	mutex    sync.Mutex
+	payloads []Payload
+	closed   bool
+	signal   chan struct{}
+}
+
+func newQueue() *queue {
+	return &queue{signal: make(chan struct{}, 1)}
+}
+
+func (q *queue) push(payload Payload) {
+	q.mutex.Lock()
+	q.payloads = append(q.payloads, payload)
+	q.mutex.Unlock()
+	q.notify()
+}
+
+// close makes pop return false once the queue is empty.
+func (q *queue) close() {
+	q.mutex.Lock()
+	q.closed = true
+	q.mutex.Unlock()
+	q.notify()
+}
+
+func (q *queue) notify() {
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+}
+
+// pop returns the oldest payload, waiting for one if the queue is empty.
+// It returns false if the queue is empty and closed, or if 'ctx' is done.
+func (q *queue) pop(ctx context.Context) (Payload, bool) {
+	for {
+		q.mutex.Lock()
+		if len(q.payloads) > 0 {
+			payload := q.payloads[0]
+			q.payloads[0] = Payload{}
+			q.payloads = q.payloads[1:]
+			q.mutex.Unlock()
+			return payload, true
+		}
+		closed := q.closed
+		q.mutex.Unlock()
+		if closed {
+			return Payload{}, false
+		}
+		select {
+		case <-q.signal:
+		case <-ctx.Done():
+			return Payload{}, false
+		}
+	}
+}
+
+func (n *Notifier) match(step *progress.Step) bool {
+	for _, filter := range n.Filters {
+		if !filter(step) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *Notifier) deliver(ctx context.Context, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	retries := n.MaxRetries
+	if retries == 0 {
+		retries = defaultMaxRetries
+	}
+	backoff := n.Backoff
+	if backoff == 0 {
+		backoff = defaultBackoff
+	}
+
+	for attempt := 0; ; attempt++ {
+		retry, err := n.post(ctx, body)
+		if err == nil || !retry || attempt >= retries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post sends 'body' once, and returns whether a failed delivery can be retried.
+func (n *Notifier) post(ctx context.Context, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	// the body is drained so the connection can be reused
+	_, _ = io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		retry := res.StatusCode < 400 || res.StatusCode >= 500 || res.StatusCode == http.StatusTooManyRequests
+		return retry, fmt.Errorf("progresswebhook: unexpected status %q", res.Status)
+	}
+	return false, nil
+}
@@ -0,0 +1,133 @@
+package progresswebhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progresswebhook"
+)
+
+func TestNotifier(t *testing.T) {
+	var (
+		mutex    sync.Mutex
+		payloads []progresswebhook.Payload
+		attempts int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		attempts++
+		if attempts == 1 { // first delivery fails and is retried
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var payload progresswebhook.Payload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		payloads = append(payloads, payload)
+	}))
+	defer server.Close()
+
+	prog := progress.New()
+	prog.AddStep("step1")
+	prog.AddStep("step2")
+	notifier := progresswebhook.Notifier{
+		URL:     server.URL,
+		Filters: []progress.SubscribeFilter{progress.FilterStates(progress.StateDone, progress.StateCanceled)},
+		Backoff: time.Millisecond,
+	}
+	stop := notifier.Attach(context.Background(), prog)
+	prog.Get("step1").Start()
+	prog.Get("step1").Done()
+	prog.Get("step2").Cancel()
+	require.NoError(t, stop())
+
+	require.Equal(t, 3, attempts)
+	require.Len(t, payloads, 2)
+	require.Equal(t, "step1", payloads[0].Step.ID)
+	require.Equal(t, progress.StateInProgress, payloads[0].From)
+	require.Equal(t, progress.StateDone, payloads[0].To)
+	require.Equal(t, "step2", payloads[1].Step.ID)
+	require.Equal(t, progress.StateNotStarted, payloads[1].From)
+	require.Equal(t, progress.StateCanceled, payloads[1].To)
+}
+
+func TestNotifier_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	prog := progress.New()
+	prog.AddStep("step1")
+	notifier := progresswebhook.Notifier{URL: server.URL, MaxRetries: -1}
+	stop := notifier.Attach(context.Background(), prog)
+	prog.Get("step1").Done()
+	require.EqualError(t, stop(), `progresswebhook: unexpected status "500 Internal Server Error"`)
+}
+
+func TestNotifier_clientError(t *testing.T) {
+	for _, test := range []struct {
+		status   int
+		attempts int
+	}{
+		{http.StatusBadRequest, 1},
+		{http.StatusTooManyRequests, 3},
+	} {
+		var attempts int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(test.status)
+		}))
+
+		prog := progress.New()
+		prog.AddStep("step1")
+		notifier := progresswebhook.Notifier{URL: server.URL, MaxRetries: 2, Backoff: time.Millisecond}
+		stop := notifier.Attach(context.Background(), prog)
+		prog.Get("step1").Done()
+		require.Error(t, stop())
+		require.Equal(t, int32(test.attempts), atomic.LoadInt32(&attempts), test.status)
+		server.Close()
+	}
+}
+
+func TestNotifier_slowEndpoint(t *testing.T) {
+	var (
+		release     = make(chan struct{})
+		releaseOnce sync.Once
+		received    int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		atomic.AddInt32(&received, 1)
+	}))
+	defer server.Close()
+	unblock := func() { releaseOnce.Do(func() { close(release) }) }
+	defer unblock()
+
+	prog := progress.New()
+	for i := 0; i < 200; i++ {
+		prog.AddStep(fmt.Sprintf("step%d", i))
+	}
+	notifier := progresswebhook.Notifier{URL: server.URL}
+	stop := notifier.Attach(context.Background(), prog)
+
+	// the transitions are queued while the endpoint is blocked, without blocking the Progress
+	started := time.Now()
+	for i := 0; i < 200; i++ {
+		prog.Get(fmt.Sprintf("step%d", i)).Start().Done()
+	}
+	require.Less(t, time.Since(started), time.Second)
+
+	unblock()
+	require.NoError(t, stop())
+	require.Equal(t, int32(400), atomic.LoadInt32(&received))
+}
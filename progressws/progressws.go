@@ -0,0 +1,85 @@
+// Package progressws provides a WebSocket handler pushing Progress updates to connected clients.
+package progressws // import "moul.io/progress/progressws"
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"moul.io/progress"
+)
+
+// Message types sent by the Handler.
+const (
+	TypeState = "state"
+	TypeStep  = "step"
+	TypeDone  = "done"
+)
+
+// Message is the JSON payload of each WebSocket message.
+//
+// The first message has the "state" type and contains the full Progress.
+// Each step change then sends a "step" message with the changed Step and the new Snapshot.
+// A final "done" message is sent when the Progress becomes terminal, before closing the connection.
+type Message struct {
+	Type     string             `json:"type"`
+	Progress json.RawMessage    `json:"progress,omitempty"`
+	Step     *progress.Step     `json:"step,omitempty"`
+	Snapshot *progress.Snapshot `json:"snapshot,omitempty"`
+}
+
+// Handler returns an http.Handler upgrading requests to WebSocket connections and pushing 'prog' updates.
+// 'opts' may be nil.
+func Handler(prog *progress.Progress, opts *websocket.AcceptOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, opts)
+		if err != nil {
+			return
+		}
+		defer conn.CloseNow()
+
+		// messages sent by the client are ignored, the context is canceled when the client disconnects
+		ctx := conn.CloseRead(r.Context())
+		if err := serve(ctx, conn, prog); err != nil {
+			return
+		}
+		conn.Close(websocket.StatusNormalClosure, "")
+	})
+}
+
+func serve(ctx context.Context, conn *websocket.Conn, prog *progress.Progress) error {
+	// subscribe before sending the initial state to avoid missing updates
+	subscriber := prog.Subscribe()
+	defer prog.Unsubscribe(subscriber)
+	// the subscriber of an already terminal Progress is never closed, the stream ends right away
+	terminal := prog.IsDone()
+
+	state, err := json.Marshal(prog)
+	if err != nil {
+		return err
+	}
+	if err := wsjson.Write(ctx, conn, Message{Type: TypeState, Progress: state}); err != nil {
+		return err
+	}
+	if terminal {
+		snapshot := prog.Snapshot()
+		return wsjson.Write(ctx, conn, Message{Type: TypeDone, Snapshot: &snapshot})
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case step, ok := <-subscriber:
+			snapshot := prog.Snapshot()
+			if !ok {
+				return wsjson.Write(ctx, conn, Message{Type: TypeDone, Snapshot: &snapshot})
+			}
+			if err := wsjson.Write(ctx, conn, Message{Type: TypeStep, Step: step, Snapshot: &snapshot}); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package progressws_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progressws"
+)
+
+func TestHandler(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1")
+	server := httptest.NewServer(progressws.Handler(prog, nil))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	defer conn.CloseNow()
+
+	var msg progressws.Message
+	require.NoError(t, wsjson.Read(ctx, conn, &msg))
+	require.Equal(t, progressws.TypeState, msg.Type)
+	var state progress.Progress
+	require.NoError(t, state.UnmarshalJSON(msg.Progress))
+	require.Len(t, state.Steps, 1)
+
+	prog.Get("step1").Start()
+	msg = progressws.Message{}
+	require.NoError(t, wsjson.Read(ctx, conn, &msg))
+	require.Equal(t, progressws.TypeStep, msg.Type)
+	require.Equal(t, "step1", msg.Step.ID)
+	require.Equal(t, progress.StateInProgress, msg.Step.State)
+	require.Equal(t, progress.StateInProgress, msg.Snapshot.State)
+
+	prog.Get("step1").Done()
+	msg = progressws.Message{}
+	require.NoError(t, wsjson.Read(ctx, conn, &msg))
+	require.Equal(t, progressws.TypeStep, msg.Type)
+	msg = progressws.Message{}
+	require.NoError(t, wsjson.Read(ctx, conn, &msg))
+	require.Equal(t, progressws.TypeDone, msg.Type)
+	require.Equal(t, progress.StateDone, msg.Snapshot.State)
+
+	_, _, err = conn.Read(ctx)
+	require.Equal(t, websocket.StatusNormalClosure, websocket.CloseStatus(err))
+}
+
+func TestHandler_terminal(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Done()
+	server := httptest.NewServer(progressws.Handler(prog, nil))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	defer conn.CloseNow()
+
+	var msg progressws.Message
+	require.NoError(t, wsjson.Read(ctx, conn, &msg))
+	require.Equal(t, progressws.TypeState, msg.Type)
+	msg = progressws.Message{}
+	require.NoError(t, wsjson.Read(ctx, conn, &msg))
+	require.Equal(t, progressws.TypeDone, msg.Type)
+	require.Equal(t, progress.StateDone, msg.Snapshot.State)
+
+	_, _, err = conn.Read(ctx)
+	require.Equal(t, websocket.StatusNormalClosure, websocket.CloseStatus(err))
+}
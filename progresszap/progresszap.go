@@ -0,0 +1,76 @@
+// Package progresszap provides a zap adapter logging the transitions of a Progress.
+package progresszap // import "moul.io/progress/progresszap"
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"moul.io/progress"
+)
+
+// Levels configures the level used for each step state; states missing from the map are logged at Info.
+type Levels map[progress.State]zapcore.Level
+
+// DefaultLevels logs steps at the Debug level while running, canceled steps at the Warn level,
+// and failed steps at the Error level.
+var DefaultLevels = Levels{
+	progress.StateNotStarted: zapcore.DebugLevel,
+	progress.StateInProgress: zapcore.DebugLevel,
+	progress.StateCanceled:   zapcore.WarnLevel,
+	progress.StateFailed:     zapcore.ErrorLevel,
+}
+
+// Hook logs an entry on 'logger' for each step transition of 'prog', and a summary entry when the Progress
+// becomes terminal, until the returned stop function is called.
+// 'levels' selects the level of each entry depending on the new step state; DefaultLevels is used if nil.
+// The summary entry uses the level of the final Progress state.
+func Hook(prog *progress.Progress, logger *zap.Logger, levels Levels) (stop func()) {
+	if levels == nil {
+		levels = DefaultLevels
+	}
+	level := func(state progress.State) zapcore.Level {
+		if level, found := levels[state]; found {
+			return level
+		}
+		return zapcore.InfoLevel
+	}
+
+	subscriber := prog.SubscribeEvents()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for event := range subscriber {
+			step := event.Step
+			fields := []zap.Field{
+				zap.String("step", event.StepID),
+				zap.String("from", string(event.From)),
+				zap.String("state", string(event.To)),
+				zap.Duration("duration", step.Duration()),
+			}
+			if step.Description != "" {
+				fields = append(fields, zap.String("description", step.Description))
+			}
+			if step.Error != "" {
+				fields = append(fields, zap.String("error", step.Error))
+			}
+			logger.Log(level(event.To), "progress step transition", fields...)
+		}
+
+		snapshot := prog.Snapshot()
+		if !snapshot.State.IsTerminal() {
+			return
+		}
+		logger.Log(level(snapshot.State), "progress summary",
+			zap.String("state", string(snapshot.State)),
+			zap.Int("completed", snapshot.Completed),
+			zap.Int("canceled", snapshot.Canceled),
+			zap.Int("failed", snapshot.Failed),
+			zap.Int("skipped", snapshot.Skipped),
+			zap.Int("total", snapshot.Total),
+			zap.Duration("duration", snapshot.TotalDuration),
+		)
+	}()
+	return func() {
+		prog.UnsubscribeEvents(subscriber)
+		<-done
+	}
+}
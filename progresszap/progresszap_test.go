@@ -0,0 +1,65 @@
+package progresszap_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"moul.io/progress"
+	"moul.io/progress/progresszap"
+)
+
+func TestHook(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	prog := progress.New()
+	prog.AddStep("step1")
+	prog.AddStep("step2")
+	prog.AddStep("step3")
+	prog.AddStep("step4")
+	stop := progresszap.Hook(prog, zap.New(core), nil)
+	prog.Get("step1").Start()
+	prog.Get("step1").Done()
+	prog.Get("step2").Cancel()
+	prog.Get("step3").Fail(errors.New("oops"))
+	prog.Get("step4").Skip("")
+	stop()
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 6)
+	require.Equal(t, zapcore.DebugLevel, entries[0].Level)
+	require.Equal(t, "not started", entries[0].ContextMap()["from"])
+	require.Equal(t, "in progress", entries[0].ContextMap()["state"])
+	require.Equal(t, zapcore.InfoLevel, entries[1].Level)
+	require.Equal(t, "in progress", entries[1].ContextMap()["from"])
+	require.Equal(t, "done", entries[1].ContextMap()["state"])
+	require.Equal(t, zapcore.WarnLevel, entries[2].Level)
+	require.Equal(t, "step2", entries[2].ContextMap()["step"])
+	require.Equal(t, "not started", entries[2].ContextMap()["from"])
+	require.Equal(t, zapcore.ErrorLevel, entries[3].Level)
+	require.Equal(t, "oops", entries[3].ContextMap()["error"])
+	summary := entries[5]
+	require.Equal(t, "progress summary", summary.Message)
+	require.Equal(t, zapcore.ErrorLevel, summary.Level)
+	require.Equal(t, int64(4), summary.ContextMap()["total"])
+	require.Equal(t, int64(1), summary.ContextMap()["completed"])
+	require.Equal(t, int64(1), summary.ContextMap()["canceled"])
+	require.Equal(t, int64(1), summary.ContextMap()["failed"])
+	require.Equal(t, int64(1), summary.ContextMap()["skipped"])
+}
+
+func TestHook_levels(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	prog := progress.New()
+	prog.AddStep("step1")
+	stop := progresszap.Hook(prog, zap.New(core), progresszap.Levels{progress.StateDone: zapcore.ErrorLevel})
+	prog.Get("step1").Done()
+	stop()
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+	require.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+	require.Equal(t, "progress summary", entries[1].Message)
+}
@@ -0,0 +1,77 @@
+package progress
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+)
+
+// Open restores a Progress previously written by SaveFile or AttachFile, configured with 'opts',
+// i.e., WithDataCodec to decode the data of the steps.
+// The steps that were in progress when the file was written are marked as StateInterrupted,
+// they can be started again to resume the work, see FirstUnfinished.
+func Open(path string, opts ...Option) (*Progress, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return open(data, opts)
+}
+
+// OpenStore is equivalent to Open, but restores the Progress saved in 'store' with 'id'.
+func OpenStore(ctx context.Context, store Store, id string, opts ...Option) (*Progress, error) {
+	loaded, err := store.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// the stores decode the data generically, it is decoded again with the options
+	data, err := json.Marshal(loaded)
+	if err != nil {
+		return nil, err
+	}
+	return open(data, opts)
+}
+
+// open decodes a JSON document into a Progress configured with 'opts'.
+// Unlike New, the auto save is only started once the document is restored.
+func open(data []byte, opts []Option) (*Progress, error) {
+	prog := &Progress{}
+	for _, opt := range opts {
+		opt(prog)
+	}
+	if err := json.Unmarshal(data, prog); err != nil {
+		return nil, err
+	}
+	prog.interrupt()
+	if prog.autoSave != nil {
+		prog.stopAutoSave = prog.autoSave()
+	}
+	return prog, nil
+}
+
+// FirstUnfinished returns the first step that is not in a terminal state.
+// If every step is terminal, nil is returned.
+func (p *Progress) FirstUnfinished() *Step {
+	p.mainMutex.RLock()
+	defer p.mainMutex.RUnlock()
+	for _, step := range p.Steps {
+		if !step.State.IsTerminal() {
+			return step
+		}
+	}
+	return nil
+}
+
+// interrupt marks the in-progress steps as interrupted.
+func (p *Progress) interrupt() {
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	now := p.now()
+	for _, step := range p.Steps {
+		if step.State == StateInProgress {
+			step.setState(StateInterrupted, now)
+			step.Progress = notStartedProgress
+			p.publishStep(step)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package progress_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+type memoryStore map[string]*progress.Progress
+
+func (s memoryStore) Save(_ context.Context, id string, prog *progress.Progress) error {
+	s[id] = prog
+	return nil
+}
+
+func (s memoryStore) Load(_ context.Context, id string) (*progress.Progress, error) {
+	prog, found := s[id]
+	if !found {
+		return nil, progress.ErrProgressNotFound
+	}
+	return prog, nil
+}
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+	prog := progress.New()
+	prog.AddStep("step1").Done()
+	prog.AddStep("step2").SetProgress(0.4)
+	prog.AddStep("step3")
+	require.NoError(t, prog.SaveFile(path))
+
+	// simulate a crash + restart
+	restored, err := progress.Open(path)
+	require.NoError(t, err)
+	require.Equal(t, progress.StateDone, restored.Get("step1").State)
+	require.Equal(t, progress.StateInterrupted, restored.Get("step2").State)
+	require.Equal(t, progress.StateNotStarted, restored.Get("step3").State)
+	events := restored.Events()
+	require.Equal(t, progress.StateInterrupted, events[len(events)-1].To)
+
+	snapshot := restored.Snapshot()
+	require.Equal(t, progress.StateStopped, snapshot.State)
+	require.Equal(t, 1, snapshot.Completed)
+	require.Equal(t, 1, snapshot.Interrupted)
+	require.Equal(t, 1, snapshot.NotStarted)
+	require.Equal(t, 1.0/3, snapshot.Progress)
+
+	// resume from the first unfinished step
+	next := restored.FirstUnfinished()
+	require.Equal(t, "step2", next.ID)
+	next.Start()
+	next.Done()
+	next = restored.FirstUnfinished()
+	require.Equal(t, "step3", next.ID)
+	next.Done()
+	require.Nil(t, restored.FirstUnfinished())
+	require.Equal(t, progress.StateDone, restored.Snapshot().State)
+
+	_, err = progress.Open(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestOpenStore(t *testing.T) {
+	store := memoryStore{}
+	ctx := context.Background()
+	_, err := progress.OpenStore(ctx, store, "job")
+	require.Equal(t, progress.ErrProgressNotFound, err)
+
+	prog := progress.New()
+	prog.AddStep("step1").Start()
+	require.NoError(t, store.Save(ctx, "job", prog))
+
+	restored, err := progress.OpenStore(ctx, store, "job")
+	require.NoError(t, err)
+	require.Equal(t, progress.StateInterrupted, restored.Get("step1").State)
+	require.Equal(t, progress.StateNotStarted, restored.Snapshot().State)
+}
@@ -0,0 +1,158 @@
+package progress
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// RedactField identifies a step field that can be redacted from exports.
+type RedactField string
+
+const (
+	RedactData        RedactField = "data"
+	RedactDescription RedactField = "description"
+	RedactActor       RedactField = "actor"
+	RedactLogs        RedactField = "logs"
+	RedactError       RedactField = "error"
+	RedactMetadata    RedactField = "metadata"
+)
+
+// RedactionMode defines how redacted fields are exported.
+type RedactionMode int
+
+const (
+	// RedactOmit removes the redacted fields.
+	RedactOmit RedactionMode = iota
+	// RedactHash replaces the redacted fields with a truncated SHA-256 of their value, i.e., "sha256:0123456789abcdef",
+	// so identical values can still be correlated.
+	RedactHash
+)
+
+// Redacted returns a detached copy of the Progress with the provided step 'fields' redacted.
+// RedactError covers the error messages of the steps and of their attempts, and RedactMetadata covers the
+// metadata values, keeping their keys in RedactHash mode.
+// The children added with AddChild are redacted the same way.
+// The copy can be exported using any of the supported formats.
+func (p *Progress) Redacted(mode RedactionMode, fields ...RedactField) *Progress {
+	redact := map[RedactField]bool{}
+	for _, field := range fields {
+		redact[field] = true
+	}
+
+	ret := p.clone()
+	for _, step := range ret.Steps {
+		if step.child != nil {
+			step.child = step.child.Redacted(mode, fields...)
+		}
+		if redact[RedactData] && step.Data != nil {
+			step.Data = redactValue(mode, step.Data)
+		}
+		if redact[RedactDescription] && step.Description != "" {
+			step.Description, _ = redactValue(mode, step.Description).(string)
+		}
+		if redact[RedactActor] && step.Actor != "" {
+			step.Actor, _ = redactValue(mode, step.Actor).(string)
+		}
+		if redact[RedactError] {
+			step.err = nil
+			if step.Error != "" {
+				step.Error, _ = redactValue(mode, step.Error).(string)
+			}
+			for idx := range step.Attempts {
+				if step.Attempts[idx].Error != "" {
+					step.Attempts[idx].Error, _ = redactValue(mode, step.Attempts[idx].Error).(string)
+				}
+			}
+		}
+		if redact[RedactMetadata] && step.Metadata != nil {
+			if mode == RedactOmit {
+				step.Metadata = nil
+			}
+			for key, value := range step.Metadata {
+				step.Metadata[key], _ = redactValue(mode, value).(string)
+			}
+		}
+		if redact[RedactLogs] && step.Logs != nil {
+			if mode == RedactOmit {
+				step.Logs = nil
+			}
+			for idx := range step.Logs {
+				step.Logs[idx].Message, _ = redactValue(mode, step.Logs[idx].Message).(string)
+			}
+		}
+	}
+	if redact[RedactActor] {
+		for idx := range ret.events {
+			if ret.events[idx].Actor != "" {
+				ret.events[idx].Actor, _ = redactValue(mode, ret.events[idx].Actor).(string)
+			}
+		}
+	}
+	return ret
+}
+
+// MarshalJSONRedacted is equivalent to MarshalJSON with the provided step 'fields' redacted, see Redacted.
+func (p *Progress) MarshalJSONRedacted(mode RedactionMode, fields ...RedactField) ([]byte, error) {
+	return json.Marshal(p.Redacted(mode, fields...))
+}
+
+func redactValue(mode RedactionMode, value interface{}) interface{} {
+	if mode != RedactHash {
+		return nil
+	}
+	var raw []byte
+	switch typed := value.(type) {
+	case string:
+		raw = []byte(typed)
+	default:
+		var err error
+		if raw, err = json.Marshal(value); err != nil {
+			raw = []byte(fmt.Sprintf("%#v", value))
+		}
+	}
+	sum := sha256.Sum256(raw)
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
+
+// clone returns a detached deep copy of the Progress, without subscribers.
+// Step.Data values are shared with the original.
+func (p *Progress) clone() *Progress {
+	p.mainMutex.RLock()
+	defer p.mainMutex.RUnlock()
+	ret := &Progress{
+		CreatedAt:       p.CreatedAt,
+		eventLogLimit:   p.eventLogLimit,
+		dataCodec:       p.dataCodec,
+		durationFormat:  p.durationFormat,
+		clock:           p.clock,
+		safeMode:        p.safeMode,
+		strictMode:      p.strictMode,
+		strictHandler:   p.strictHandler,
+		percentStrategy: p.percentStrategy,
+		stepLogLimit:    p.stepLogLimit,
+		doingFormat:     p.doingFormat,
+		humanDurations:  p.humanDurations,
+		percentFormat:   p.percentFormat,
+		expectedTotal:   p.expectedTotal,
+		indeterminate:   p.indeterminate,
+		autoAdvance:     p.autoAdvance,
+		staleThreshold:  p.staleThreshold,
+	}
+	if p.Steps != nil {
+		ret.Steps = make([]*Step, 0, len(p.Steps))
+	}
+	for _, step := range p.Steps {
+		stepCopy := step.clone()
+		stepCopy.parent = ret
+		stepCopy.doneCh = nil
+		stepCopy.doneChClosed = false
+		ret.Steps = append(ret.Steps, stepCopy)
+	}
+	if p.events != nil {
+		ret.events = make([]Event, len(p.events))
+		copy(ret.events, p.events)
+	}
+	return ret
+}
@@ -0,0 +1,106 @@
+package progress_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progresstest"
+)
+
+func TestRedacted(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").SetDescription("customer 42").SetData(map[string]string{"email": "foo@bar.com"}).Done()
+	prog.AddStep("step2").SetDescription("customer 42").SetActor("alice").Start()
+	prog.AddStep("step3")
+
+	// omit
+	{
+		redacted := prog.Redacted(progress.RedactOmit, progress.RedactData, progress.RedactDescription)
+		require.Nil(t, redacted.Get("step1").Data)
+		require.Empty(t, redacted.Get("step1").Description)
+		require.Equal(t, "alice", redacted.Get("step2").Actor)
+		require.Equal(t, "step2", redacted.Snapshot().Doing)
+
+		// the original progress is untouched
+		require.Equal(t, "customer 42", prog.Get("step1").Description)
+		require.NotNil(t, prog.Get("step1").Data)
+	}
+
+	// hash
+	{
+		out, err := prog.MarshalJSONRedacted(progress.RedactHash, progress.RedactData, progress.RedactDescription, progress.RedactActor)
+		require.NoError(t, err)
+		require.NotContains(t, string(out), "customer")
+		require.NotContains(t, string(out), "foo@bar.com")
+		require.NotContains(t, string(out), "alice")
+
+		var loaded progress.Progress
+		require.NoError(t, json.Unmarshal(out, &loaded))
+		desc1 := loaded.Get("step1").Description
+		require.Regexp(t, `^sha256:[0-9a-f]{16}$`, desc1)
+		require.Equal(t, desc1, loaded.Get("step2").Description)
+		require.Regexp(t, `^sha256:[0-9a-f]{16}$`, loaded.Get("step1").Data)
+		require.Regexp(t, `^sha256:[0-9a-f]{16}$`, loaded.Get("step2").Actor)
+		require.Empty(t, loaded.Get("step3").Description)
+		for _, event := range loaded.Events() {
+			require.NotEqual(t, "alice", event.Actor)
+		}
+	}
+}
+
+func TestRedacted_keepsOptions(t *testing.T) {
+	clock := progresstest.NewClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	prog := progress.New(progress.WithClock(clock))
+	prog.AddStep("step1").Start()
+	clock.Advance(time.Minute)
+	require.Equal(t, time.Minute, prog.Redacted(progress.RedactOmit).Snapshot().TotalDuration)
+}
+
+func TestRedacted_children(t *testing.T) {
+	child := progress.New()
+	child.AddStep("fetch").SetDescription("customer 42").Start()
+	prog := progress.New()
+	prog.AddChild("deploy", child)
+
+	redacted := prog.Redacted(progress.RedactHash, progress.RedactDescription)
+	require.Regexp(t, `^sha256:[0-9a-f]{16}$`, redacted.Snapshot().Children["deploy"].Doing)
+	out, err := json.Marshal(redacted)
+	require.NoError(t, err)
+	require.NotContains(t, string(out), "customer")
+
+	// the original child is untouched
+	require.Equal(t, "customer 42", child.Snapshot().Doing)
+	require.Equal(t, "customer 42", prog.Snapshot().Children["deploy"].Doing)
+}
+
+func TestRedacted_errorAndMetadata(t *testing.T) {
+	var prog progress.Progress
+	require.NoError(t, json.Unmarshal([]byte(`{"steps":[{"id":"step1","state":"failed","error":"dial postgres://admin:secret@db",`+
+		`"attempts":[{"error":"dial postgres://admin:secret@db"}],"metadata":{"token":"secret"}}]}`), &prog))
+	prog.Get("step1").SetMeta("region", "eu")
+
+	hashed := prog.Redacted(progress.RedactHash, progress.RedactError, progress.RedactMetadata)
+	step := hashed.Get("step1")
+	require.Regexp(t, `^sha256:[0-9a-f]{16}$`, step.Error)
+	require.Equal(t, step.Error, step.Attempts[0].Error)
+	require.EqualError(t, step.Err(), step.Error)
+	require.Len(t, step.Metadata, 2)
+	require.Regexp(t, `^sha256:[0-9a-f]{16}$`, step.Metadata["token"])
+	out, err := json.Marshal(hashed)
+	require.NoError(t, err)
+	require.NotContains(t, string(out), "secret")
+
+	omitted := prog.Redacted(progress.RedactOmit, progress.RedactError, progress.RedactMetadata)
+	step = omitted.Get("step1")
+	require.Empty(t, step.Error)
+	require.Empty(t, step.Attempts[0].Error)
+	require.NoError(t, step.Err())
+	require.Nil(t, step.Metadata)
+
+	// the original progress is untouched
+	require.Equal(t, "dial postgres://admin:secret@db", prog.Get("step1").Error)
+	require.Equal(t, "secret", prog.Get("step1").Metadata["token"])
+}
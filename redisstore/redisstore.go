@@ -0,0 +1,384 @@
+// Package redisstore provides a Redis implementation of progress.Store that can be shared by several processes.
+//
+// Each step is stored in its own hash, so several workers can report the steps they own into the same logical
+// Progress using Attach, while any process can Load the combined state or Subscribe to the updates.
+package redisstore // import "moul.io/progress/redisstore"
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"moul.io/progress"
+)
+
+const defaultPrefix = "progress"
+
+// Store persists progresses in Redis.
+type Store struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+var _ progress.Store = (*Store)(nil)
+
+// New returns a Store using 'client'.
+// Every key is prefixed with 'prefix', or with "progress" if empty.
+func New(client redis.UniversalClient, prefix string) *Store {
+	if prefix == "" {
+		prefix = defaultPrefix
+	}
+	return &Store{client: client, prefix: prefix}
+}
+
+func (s *Store) metaKey(id string) string         { return s.prefix + ":" + id }
+func (s *Store) stepsKey(id string) string        { return s.prefix + ":" + id + ":steps" }
+func (s *Store) stepKey(id, stepID string) string { return s.prefix + ":" + id + ":step:" + stepID }
+func (s *Store) eventsKey(id string) string       { return s.prefix + ":" + id + ":events" }
+func (s *Store) updatesChannel(id string) string  { return s.prefix + ":" + id + ":updates" }
+
+// Save implements progress.Store.
+// It replaces every step stored with 'id', including the ones reported by other processes.
+func (s *Store) Save(ctx context.Context, id string, prog *progress.Progress) error {
+	stepIDs, err := s.client.ZRange(ctx, s.stepsKey(id), 0, -1).Result()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		keys := []string{s.metaKey(id), s.stepsKey(id), s.eventsKey(id)}
+		for _, stepID := range stepIDs {
+			keys = append(keys, s.stepKey(id, stepID))
+		}
+		pipe.Del(ctx, keys...)
+		pipe.HSet(ctx, s.metaKey(id), "created_at", formatTime(prog.CreatedAt))
+		for position, step := range slices.Collect(prog.AllSteps()) {
+			if err := s.writeStep(ctx, pipe, id, step, float64(position), false); err != nil {
+				return err
+			}
+		}
+		return s.writeEvents(ctx, pipe, id, prog.Events())
+	})
+	return err
+}
+
+// Load implements progress.Store.
+// It returns the combined state of the steps reported by every process.
+func (s *Store) Load(ctx context.Context, id string) (*progress.Progress, error) {
+	// the JSON representation is used as a pivot to attach the steps and the events to the Progress
+	var decoded struct {
+		Steps     []*progress.Step `json:"steps,omitempty"`
+		CreatedAt time.Time        `json:"created_at,omitempty"`
+		Events    []progress.Event `json:"events,omitempty"`
+	}
+
+	createdAt, err := s.client.HGet(ctx, s.metaKey(id), "created_at").Result()
+	if err == redis.Nil {
+		return nil, progress.ErrProgressNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if decoded.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return nil, err
+	}
+
+	stepIDs, err := s.client.ZRange(ctx, s.stepsKey(id), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	cmds := make([]*redis.MapStringStringCmd, len(stepIDs))
+	_, err = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for idx, stepID := range stepIDs {
+			cmds[idx] = pipe.HGetAll(ctx, s.stepKey(id, stepID))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for idx, cmd := range cmds {
+		step, err := decodeStep(stepIDs[idx], cmd.Val())
+		if err != nil {
+			return nil, err
+		}
+		decoded.Steps = append(decoded.Steps, step)
+	}
+
+	rawEvents, err := s.client.LRange(ctx, s.eventsKey(id), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	for _, rawEvent := range rawEvents {
+		var event progress.Event
+		if err := json.Unmarshal([]byte(rawEvent), &event); err != nil {
+			return nil, err
+		}
+		decoded.Events = append(decoded.Events, event)
+	}
+
+	raw, err := json.Marshal(decoded)
+	if err != nil {
+		return nil, err
+	}
+	var prog progress.Progress
+	if err := json.Unmarshal(raw, &prog); err != nil {
+		return nil, err
+	}
+	return &prog, nil
+}
+
+// Attach reports every change of the local 'prog' into the shared progress stored with 'id'.
+// Only the steps of 'prog' are written, so several processes can attach their own steps to the same 'id'.
+// Each changed step is also published to the subscribers of 'id', see Subscribe.
+// It blocks until 'ctx' is done and returns the context error, or the first Redis error.
+func (s *Store) Attach(ctx context.Context, id string, prog *progress.Progress) error {
+	if err := s.client.HSetNX(ctx, s.metaKey(id), "created_at", formatTime(prog.CreatedAt)).Err(); err != nil {
+		return err
+	}
+
+	var lastEvent *progress.Event
+	push := func(steps ...*progress.Step) error {
+		events := prog.EventsSince(lastEvent)
+		if len(events) > 0 {
+			lastEvent = &events[len(events)-1]
+		}
+		_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, step := range steps {
+				position := float64(time.Now().UnixNano())
+				if err := s.writeStep(ctx, pipe, id, step, position, true); err != nil {
+					return err
+				}
+			}
+			return s.writeEvents(ctx, pipe, id, events)
+		})
+		return err
+	}
+
+	sub := prog.Subscribe()
+	defer prog.Unsubscribe(sub)
+	if err := push(slices.Collect(prog.AllSteps())...); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case step, ok := <-sub:
+			if !ok {
+				// the progress became terminal, subscribe again to catch the steps added later
+				sub = prog.Subscribe()
+				continue
+			}
+			if err := push(step); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Subscribe returns a chan receiving the steps published by the processes attached to 'id'.
+// The chan is closed when 'ctx' is done.
+func (s *Store) Subscribe(ctx context.Context, id string) (<-chan *progress.Step, error) {
+	pubsub := s.client.Subscribe(ctx, s.updatesChannel(id))
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	ch := make(chan *progress.Step)
+	go func() {
+		defer close(ch)
+		defer pubsub.Close()
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				var step progress.Step
+				if err := json.Unmarshal([]byte(msg.Payload), &step); err != nil {
+					continue
+				}
+				select {
+				case ch <- &step:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (s *Store) writeStep(ctx context.Context, pipe redis.Pipeliner, id string, step *progress.Step, position float64, publish bool) error {
+	fields := map[string]interface{}{
+		"description": step.Description,
+		"state":       string(step.State),
+		"progress":    strconv.FormatFloat(step.Progress, 'f', -1, 64),
+		"actor":       step.Actor,
+		"group":       step.Group,
+		"error":       step.Error,
+		"skip_reason": step.SkipReason,
+		"units":       strconv.FormatInt(step.Units, 10),
+		"total_units": strconv.FormatInt(step.TotalUnits, 10),
+		"started_at":  formatTimePtr(step.StartedAt),
+		"done_at":     formatTimePtr(step.DoneAt),
+		"data":        "",
+		"attempts":    "",
+		"tags":        "",
+		"metadata":    "",
+		"logs":        "",
+	}
+	if step.Data != nil {
+		// the data is encoded with the DataCodec of the Progress, if any
+		raw, err := step.MarshalData()
+		if err != nil {
+			return err
+		}
+		fields["data"] = string(raw)
+	}
+	if len(step.Attempts) > 0 {
+		raw, err := json.Marshal(step.Attempts)
+		if err != nil {
+			return err
+		}
+		fields["attempts"] = string(raw)
+	}
+	if len(step.Tags) > 0 {
+		raw, err := json.Marshal(step.Tags)
+		if err != nil {
+			return err
+		}
+		fields["tags"] = string(raw)
+	}
+	if len(step.Metadata) > 0 {
+		raw, err := json.Marshal(step.Metadata)
+		if err != nil {
+			return err
+		}
+		fields["metadata"] = string(raw)
+	}
+	if len(step.Logs) > 0 {
+		raw, err := json.Marshal(step.Logs)
+		if err != nil {
+			return err
+		}
+		fields["logs"] = string(raw)
+	}
+	pipe.ZAddNX(ctx, s.stepsKey(id), redis.Z{Score: position, Member: step.ID})
+	pipe.HSet(ctx, s.stepKey(id, step.ID), fields)
+
+	if publish {
+		raw, err := json.Marshal(step)
+		if err != nil {
+			return err
+		}
+		pipe.Publish(ctx, s.updatesChannel(id), raw)
+	}
+	return nil
+}
+
+func (s *Store) writeEvents(ctx context.Context, pipe redis.Pipeliner, id string, events []progress.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	values := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		raw, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		values = append(values, raw)
+	}
+	pipe.RPush(ctx, s.eventsKey(id), values...)
+	return nil
+}
+
+func decodeStep(id string, fields map[string]string) (*progress.Step, error) {
+	step := progress.Step{
+		ID:          id,
+		Description: fields["description"],
+		State:       progress.State(fields["state"]),
+		Actor:       fields["actor"],
+		Group:       fields["group"],
+		Error:       fields["error"],
+		SkipReason:  fields["skip_reason"],
+	}
+	var err error
+	if value := fields["progress"]; value != "" {
+		if step.Progress, err = strconv.ParseFloat(value, 64); err != nil {
+			return nil, err
+		}
+	}
+	if value := fields["units"]; value != "" {
+		if step.Units, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	if value := fields["total_units"]; value != "" {
+		if step.TotalUnits, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	if step.StartedAt, err = parseTimePtr(fields["started_at"]); err != nil {
+		return nil, err
+	}
+	if step.DoneAt, err = parseTimePtr(fields["done_at"]); err != nil {
+		return nil, err
+	}
+	if value := fields["data"]; value != "" {
+		if err := json.Unmarshal([]byte(value), &step.Data); err != nil {
+			return nil, err
+		}
+	}
+	if value := fields["attempts"]; value != "" {
+		if err := json.Unmarshal([]byte(value), &step.Attempts); err != nil {
+			return nil, err
+		}
+	}
+	if value := fields["tags"]; value != "" {
+		if err := json.Unmarshal([]byte(value), &step.Tags); err != nil {
+			return nil, err
+		}
+	}
+	if value := fields["metadata"]; value != "" {
+		if err := json.Unmarshal([]byte(value), &step.Metadata); err != nil {
+			return nil, err
+		}
+	}
+	if value := fields["logs"]; value != "" {
+		if err := json.Unmarshal([]byte(value), &step.Logs); err != nil {
+			return nil, err
+		}
+	}
+	return &step, nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTime(*t)
+}
+
+func parseTimePtr(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,128 @@
+package redisstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/redisstore"
+)
+
+func newTestStore(t *testing.T) *redisstore.Store {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return redisstore.New(client, "")
+}
+
+func TestStore(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	_, err := store.Load(ctx, "migration")
+	require.Equal(t, progress.ErrProgressNotFound, err)
+
+	prog := progress.New()
+	prog.AddStep("step1").SetDescription("hello").SetData(42).Done()
+	prog.AddStep("step2").SetActor("worker-1").SetProgress(0.3)
+	prog.AddStep("step3").SetTotalUnits(100).SetGroup("build").AddTag("compute", "linux").SetMeta("host", "builder-1")
+	prog.Get("step3").Logf("fetching")
+	require.NoError(t, store.Save(ctx, "migration", prog))
+
+	loaded, err := store.Load(ctx, "migration")
+	require.NoError(t, err)
+	require.True(t, prog.CreatedAt.Equal(loaded.CreatedAt))
+	require.Len(t, loaded.Steps, 3)
+	require.Equal(t, "step1", loaded.Steps[0].ID)
+	require.Equal(t, "step3", loaded.Steps[2].ID)
+	require.Equal(t, "hello", loaded.Get("step1").Description)
+	require.Equal(t, float64(42), loaded.Get("step1").Data)
+	require.Equal(t, 0.3, loaded.Get("step2").Progress)
+	require.Equal(t, "worker-1", loaded.Get("step2").Actor)
+	require.Equal(t, progress.StateNotStarted, loaded.Get("step3").State)
+	require.Equal(t, int64(100), loaded.Get("step3").TotalUnits)
+	require.Equal(t, "build", loaded.Get("step3").Group)
+	require.Equal(t, []string{"compute", "linux"}, loaded.Get("step3").Tags)
+	require.Equal(t, "builder-1", loaded.Get("step3").Meta("host"))
+	require.Equal(t, "fetching", loaded.Get("step3").Logs[0].Message)
+	require.Equal(t, len(prog.Events()), len(loaded.Events()))
+
+	// saving again replaces the previous version
+	other := progress.New()
+	other.AddStep("foo")
+	require.NoError(t, store.Save(ctx, "migration", other))
+	loaded, err = store.Load(ctx, "migration")
+	require.NoError(t, err)
+	require.Len(t, loaded.Steps, 1)
+	require.Len(t, loaded.Events(), 1)
+}
+
+func TestStore_shared(t *testing.T) {
+	store := newTestStore(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, err := store.Subscribe(ctx, "job")
+	require.NoError(t, err)
+
+	// two workers report their own steps into the same logical progress
+	worker1 := progress.New()
+	worker1.AddStep("download")
+	worker2 := progress.New()
+	worker2.AddStep("compute")
+	errs := make(chan error, 2)
+	go func() { errs <- store.Attach(ctx, "job", worker1) }()
+	go func() { errs <- store.Attach(ctx, "job", worker2) }()
+
+	require.Eventually(t, func() bool {
+		prog, err := store.Load(ctx, "job")
+		return err == nil && len(prog.Steps) == 2
+	}, time.Second, 5*time.Millisecond)
+
+	worker1.Get("download").Start()
+	worker2.Get("compute").Done()
+
+	seen := map[string]progress.State{}
+	for len(seen) < 2 || seen["download"] != progress.StateInProgress || seen["compute"] != progress.StateDone {
+		select {
+		case step := <-updates:
+			seen[step.ID] = step.State
+		case <-time.After(time.Second):
+			t.Fatalf("missing updates: %v", seen)
+		}
+	}
+
+	combined, err := store.Load(ctx, "job")
+	require.NoError(t, err)
+	snapshot := combined.Snapshot()
+	require.Equal(t, 2, snapshot.Total)
+	require.Equal(t, 1, snapshot.InProgress)
+	require.Equal(t, 1, snapshot.Completed)
+	require.Equal(t, 4, len(combined.Events()))
+
+	cancel()
+	require.Equal(t, context.Canceled, <-errs)
+	require.Equal(t, context.Canceled, <-errs)
+}
+
+func TestStore_dataCodec(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	type user struct {
+		Name string `json:"name"`
+	}
+	registry := progress.NewTypeRegistry()
+	registry.Register("user", user{})
+	prog := progress.New(progress.WithDataCodec(registry))
+	prog.AddStep("step1").SetData(user{Name: "foo"}).Done()
+	require.NoError(t, store.Save(ctx, "job", prog))
+
+	loaded, err := progress.OpenStore(ctx, store, "job", progress.WithDataCodec(registry))
+	require.NoError(t, err)
+	require.Equal(t, user{Name: "foo"}, loaded.MustGet("step1").Data)
+}
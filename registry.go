@@ -0,0 +1,54 @@
+package progress
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrNameAlreadyRegistered is returned by Register when the name is already used.
+var ErrNameAlreadyRegistered = errors.New("progress: a progress is already registered with the provided name")
+
+var registry = struct {
+	sync.RWMutex
+	progresses map[string]*Progress
+}{progresses: make(map[string]*Progress)}
+
+// Register adds 'prog' to the process-wide registry under 'name', so diagnostics endpoints and signal
+// handlers can enumerate the live progresses with List and Lookup.
+// It returns ErrNameAlreadyRegistered if 'name' is already used; see Unregister.
+func Register(name string, prog *Progress) error {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, found := registry.progresses[name]; found {
+		return ErrNameAlreadyRegistered
+	}
+	registry.progresses[name] = prog
+	return nil
+}
+
+// Unregister removes the progress registered under 'name', if any.
+func Unregister(name string) {
+	registry.Lock()
+	defer registry.Unlock()
+	delete(registry.progresses, name)
+}
+
+// Lookup returns the progress registered under 'name', or nil.
+func Lookup(name string) *Progress {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registry.progresses[name]
+}
+
+// List returns the names of the registered progresses, sorted.
+func List() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(registry.progresses))
+	for name := range registry.progresses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
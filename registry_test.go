@@ -0,0 +1,25 @@
+package progress_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestRegistry(t *testing.T) {
+	migration := progress.New()
+	backup := progress.New()
+	require.NoError(t, progress.Register("test-migration", migration))
+	require.NoError(t, progress.Register("test-backup", backup))
+	defer progress.Unregister("test-backup")
+	require.Equal(t, progress.ErrNameAlreadyRegistered, progress.Register("test-migration", backup))
+
+	require.Equal(t, migration, progress.Lookup("test-migration"))
+	require.Nil(t, progress.Lookup("test-unknown"))
+	require.Subset(t, progress.List(), []string{"test-backup", "test-migration"})
+
+	progress.Unregister("test-migration")
+	require.Nil(t, progress.Lookup("test-migration"))
+	require.NotContains(t, progress.List(), "test-migration")
+}
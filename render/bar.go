@@ -0,0 +1,111 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"moul.io/progress"
+)
+
+const defaultBarWidth = 30
+
+// Bar renders a single-line progress bar, redrawn in place:
+//
+//	[=============>                ]  45% ⠹ step2 eta 12s
+//
+// A spinner is animated while steps are in progress, and replaced by a checkmark or a cross at the end.
+// For indeterminate progresses, a block bouncing across the bar replaces the percentage, see
+// progress.Progress.SetIndeterminate.
+type Bar struct {
+	// Width is the number of cells of the bar, 30 if zero.
+	Width int
+	// Style defines the runes of the bar, StyleDefault if zero.
+	Style BarStyle
+	// Theme colors the bar depending on the Progress state.
+	Theme Theme
+	// MaxWidth is the maximum width of the line, the Doing part being ellipsized to fit;
+	// zero uses the terminal width of the writer, a negative value disables the limit.
+	MaxWidth int
+
+	w     io.Writer
+	frame int
+}
+
+var _ Renderer = (*Bar)(nil)
+
+// NewBar returns a Bar writing to 'w', usually a terminal.
+// Colors are enabled depending on 'w', see AutoTheme.
+func NewBar(w io.Writer) *Bar {
+	return &Bar{w: w, Theme: AutoTheme(w)}
+}
+
+// Render implements Renderer.
+func (b *Bar) Render(prog *progress.Progress, final bool) error {
+	line := b.line(prog.Snapshot(), maxWidth(b.MaxWidth, b.w))
+	b.frame++
+	end := ""
+	if final {
+		end = "\n"
+	}
+	// \r moves back to the beginning of the line, \x1b[K clears the rest of the previous line
+	_, err := fmt.Fprintf(b.w, "\r%s\x1b[K%s", line, end)
+	return err
+}
+
+// Line returns the bar line of 'snapshot' for the current spinner frame, without any control sequence.
+// The line is limited to MaxWidth if positive.
+func (b *Bar) Line(snapshot progress.Snapshot) string {
+	limit := b.MaxWidth
+	if limit < 0 {
+		limit = 0
+	}
+	return b.line(snapshot, limit)
+}
+
+func (b *Bar) line(snapshot progress.Snapshot, limit int) string {
+	width := b.Width
+	if width <= 0 {
+		width = defaultBarWidth
+	}
+	style := b.Style
+	if style.Full == 0 {
+		style = StyleDefault
+	}
+
+	var head strings.Builder
+	head.WriteString(style.Left)
+	if snapshot.Indeterminate {
+		before, block, after := style.throbber(b.frame, width)
+		head.WriteString(before)
+		head.WriteString(b.Theme.paint(snapshot.State, block))
+		head.WriteString(after)
+		head.WriteString(style.Right)
+	} else {
+		fill, empty := style.cells(snapshot.Progress, width)
+		head.WriteString(b.Theme.paint(snapshot.State, fill))
+		head.WriteString(empty)
+		head.WriteString(style.Right)
+		fmt.Fprintf(&head, " %4s", snapshot.FormatPercent())
+	}
+
+	var tail string
+	switch {
+	case snapshot.CompletionEstimate > 0:
+		tail = fmt.Sprintf(" eta %s", snapshot.CompletionEstimate.Round(time.Second))
+	case snapshot.State.IsTerminal():
+		tail = fmt.Sprintf(" %s in %s", b.Theme.paint(snapshot.State, icon(snapshot.State, b.frame)+" "+string(snapshot.State)), snapshot.TotalDuration.Round(time.Second))
+	}
+
+	doing := snapshot.Doing
+	if limit > 0 {
+		// 3 runes for the spinner and its surrounding spaces
+		doing = ellipsize(doing, limit-visibleLen(head.String())-visibleLen(tail)-3)
+	}
+	line := head.String()
+	if doing != "" {
+		line += fmt.Sprintf(" %s %s", b.Theme.paint(progress.StateInProgress, icon(progress.StateInProgress, b.frame)), doing)
+	}
+	return cut(line+tail, limit)
+}
@@ -0,0 +1,106 @@
+package render_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/render"
+)
+
+func TestBar(t *testing.T) {
+	bar := render.NewBar(nil)
+	bar.Width = 10
+	require.Equal(t, "[>         ]   0%", bar.Line(progress.Snapshot{}))
+	require.Equal(t, "[=====>    ]  50% ⠋ step2 eta 12s", bar.Line(progress.Snapshot{
+		Progress:           0.5,
+		Doing:              "step2",
+		CompletionEstimate: 12 * time.Second,
+	}))
+	require.Equal(t, "[==========] 100% ✓ done in 1m0s", bar.Line(progress.Snapshot{
+		State:         progress.StateDone,
+		Progress:      1,
+		TotalDuration: time.Minute,
+	}))
+}
+
+func TestBar_almostDone(t *testing.T) {
+	prog := progress.New(progress.WithPercentFormat(progress.PercentFormat{Rounding: progress.PercentRound}))
+	for i := 0; i < 199; i++ {
+		prog.AddStep(fmt.Sprintf("step%d", i)).Done()
+	}
+	prog.AddStep("last").Start()
+
+	bar := render.NewBar(nil)
+	bar.Width = 10
+	require.True(t, strings.HasPrefix(bar.Line(prog.Snapshot()), "[=========>]  99% "), bar.Line(prog.Snapshot()))
+	prog.Get("last").Done()
+	require.True(t, strings.HasPrefix(bar.Line(prog.Snapshot()), "[==========] 100% "), bar.Line(prog.Snapshot()))
+}
+
+func TestBar_indeterminate(t *testing.T) {
+	prog := progress.New()
+	prog.SetIndeterminate(true)
+	prog.AddStep("step1").Start()
+
+	bar := render.NewBar(io.Discard)
+	bar.Width = 8
+	bar.MaxWidth = -1
+	var lines []string
+	for i := 0; i < 8; i++ {
+		lines = append(lines, bar.Line(prog.Snapshot()))
+		require.NoError(t, bar.Render(prog, false))
+	}
+	require.Equal(t, []string{
+		"[==      ] ⠋ step1",
+		"[ ==     ] ⠙ step1",
+		"[  ==    ] ⠹ step1",
+		"[   ==   ] ⠸ step1",
+		"[    ==  ] ⠼ step1",
+		"[     == ] ⠴ step1",
+		"[      ==] ⠦ step1",
+		"[     == ] ⠧ step1",
+	}, lines)
+
+	prog.Get("step1").Done()
+	require.Equal(t, "[========] 100% ✓ done in 0s", bar.Line(prog.Snapshot()))
+}
+
+func TestBar_spinner(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Start()
+	prog.AddStep("step2")
+
+	var buf bytes.Buffer
+	bar := render.NewBar(&buf)
+	require.NoError(t, bar.Render(prog, false))
+	require.Contains(t, buf.String(), "⠋ step1")
+	require.NoError(t, bar.Render(prog, false))
+	require.Contains(t, buf.String(), "⠙ step1")
+
+	prog.Get("step1").Cancel()
+	prog.Get("step2").Cancel()
+	require.Contains(t, bar.Line(prog.Snapshot()), "✗ canceled")
+}
+
+func TestRun(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Start()
+
+	var buf bytes.Buffer
+	done := make(chan error)
+	go func() { done <- render.Run(context.Background(), render.NewBar(&buf), prog, time.Millisecond) }()
+	time.Sleep(10 * time.Millisecond)
+	prog.Get("step1").Done()
+	require.NoError(t, <-done)
+
+	output := buf.String()
+	require.True(t, strings.HasPrefix(output, "\r["))
+	require.True(t, strings.HasSuffix(output, "100% ✓ done in 0s\x1b[K\n"))
+}
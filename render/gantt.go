@@ -0,0 +1,137 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"moul.io/progress"
+)
+
+const defaultGanttWidth = 40
+
+// Gantt renders a timeline of the steps, showing when each one started and finished relative to the run:
+//
+//	download  │████████                                │ 3s
+//	compile   │        ▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒                 │ 12s
+//	upload    │                                        │
+//
+// Done steps are drawn with full blocks, in-progress steps with shaded blocks, and other stopped steps
+// with light shaded blocks. The block is redrawn in place.
+type Gantt struct {
+	// Width is the number of cells of the timeline, 40 if zero.
+	Width int
+	// MaxWidth is the maximum width of the lines, longer lines being ellipsized;
+	// zero uses the terminal width of the writer, a negative value disables the limit.
+	MaxWidth int
+	// Theme colors the bars depending on the step state.
+	Theme Theme
+
+	w     io.Writer
+	block block
+}
+
+var _ Renderer = (*Gantt)(nil)
+
+// NewGantt returns a Gantt renderer writing to 'w'.
+// Colors are enabled depending on 'w', see AutoTheme.
+func NewGantt(w io.Writer) *Gantt {
+	return &Gantt{w: w, Theme: AutoTheme(w)}
+}
+
+// Render implements Renderer.
+func (g *Gantt) Render(prog *progress.Progress, final bool) error {
+	steps := slices.Collect(prog.AllSteps())
+	lines := cutLines(g.Lines(steps, time.Now()), maxWidth(g.MaxWidth, g.w))
+	return g.block.write(g.w, lines, final)
+}
+
+// Lines returns the timeline lines of 'steps', using 'now' as the end of running steps.
+func (g *Gantt) Lines(steps []*progress.Step, now time.Time) []string {
+	width := g.Width
+	if width <= 0 {
+		width = defaultGanttWidth
+	}
+
+	// compute the run boundaries
+	var start, end time.Time
+	titleWidth := 0
+	for _, step := range steps {
+		if n := len([]rune(title(step))); n > titleWidth {
+			titleWidth = n
+		}
+		if step.StartedAt == nil {
+			continue
+		}
+		stepEnd := stepEnd(step, now)
+		if start.IsZero() || step.StartedAt.Before(start) {
+			start = *step.StartedAt
+		}
+		if stepEnd.After(end) {
+			end = stepEnd
+		}
+	}
+	span := end.Sub(start)
+	column := func(t time.Time) int {
+		if span <= 0 {
+			return 0
+		}
+		return int(float64(t.Sub(start)) / float64(span) * float64(width))
+	}
+
+	lines := make([]string, 0, len(steps))
+	for _, step := range steps {
+		var b strings.Builder
+		b.WriteString(title(step))
+		b.WriteString(strings.Repeat(" ", titleWidth-len([]rune(title(step)))))
+		b.WriteString(" │")
+		if step.StartedAt == nil {
+			b.WriteString(strings.Repeat(" ", width))
+			b.WriteString("│")
+			lines = append(lines, b.String())
+			continue
+		}
+
+		from, to := column(*step.StartedAt), column(stepEnd(step, now))
+		if span <= 0 {
+			to = width
+		}
+		if to >= width {
+			to = width
+		}
+		if from >= to {
+			// always draw at least a cell
+			if to == width {
+				from = to - 1
+			} else {
+				to = from + 1
+			}
+		}
+		b.WriteString(strings.Repeat(" ", from))
+		b.WriteString(g.Theme.paint(step.State, strings.Repeat(ganttCell(step.State), to-from)))
+		b.WriteString(strings.Repeat(" ", width-to))
+		fmt.Fprintf(&b, "│ %s", stepEnd(step, now).Sub(*step.StartedAt).Round(time.Second))
+		lines = append(lines, b.String())
+	}
+	return lines
+}
+
+func stepEnd(step *progress.Step, now time.Time) time.Time {
+	if step.DoneAt != nil {
+		return *step.DoneAt
+	}
+	return now
+}
+
+func ganttCell(state progress.State) string {
+	switch state {
+	case progress.StateDone:
+		return "█"
+	case progress.StateInProgress:
+		return "▒"
+	default:
+		return "░"
+	}
+}
@@ -0,0 +1,33 @@
+package render_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/render"
+)
+
+func TestGantt(t *testing.T) {
+	t0 := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) *time.Time {
+		ret := t0.Add(time.Duration(seconds) * time.Second)
+		return &ret
+	}
+	steps := []*progress.Step{
+		{ID: "download", State: progress.StateDone, StartedAt: at(0), DoneAt: at(3)},
+		{ID: "compile", State: progress.StateInProgress, StartedAt: at(2)},
+		{ID: "test", State: progress.StateCanceled, StartedAt: at(4), DoneAt: at(4)},
+		{ID: "upload", State: progress.StateNotStarted},
+	}
+
+	gantt := render.NewGantt(nil)
+	gantt.Width = 10
+	require.Equal(t, []string{
+		"download │███       │ 3s",
+		"compile  │  ▒▒▒▒▒▒▒▒│ 8s",
+		"test     │    ░     │ 0s",
+		"upload   │          │",
+	}, gantt.Lines(steps, *at(10)))
+}
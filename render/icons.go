@@ -0,0 +1,25 @@
+package render
+
+import "moul.io/progress"
+
+// SpinnerFrames are the frames of the spinner animating in-progress steps.
+var SpinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Icons are the symbols displayed for each state; in-progress steps use SpinnerFrames instead.
+var Icons = map[progress.State]string{
+	progress.StateNotStarted:  "·",
+	progress.StateDone:        "✓",
+	progress.StateCanceled:    "✗",
+	progress.StateFailed:      "✗",
+	progress.StateSkipped:     "↷",
+	progress.StateInterrupted: "!",
+	progress.StateStopped:     "‖",
+}
+
+// icon returns the symbol of 'state' for the animation 'frame'.
+func icon(state progress.State, frame int) string {
+	if state == progress.StateInProgress {
+		return SpinnerFrames[frame%len(SpinnerFrames)]
+	}
+	return Icons[state]
+}
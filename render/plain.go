@@ -0,0 +1,78 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"moul.io/progress"
+)
+
+// Plain renders a timestamped line for each step transition, without any control sequence,
+// for non-interactive outputs like CI logs:
+//
+//	[12:00:01] step2 started
+//	[12:00:41] step2 done in 40s
+//
+// Transitions are read from the Progress event log, so every transition happening between two calls to
+// Render is printed, in order. The last lines logged by a failed step follow its failure.
+type Plain struct {
+	// TimeFormat is the layout of the timestamps, "15:04:05" if empty.
+	TimeFormat string
+
+	w    io.Writer
+	last *progress.Event
+}
+
+var _ Renderer = (*Plain)(nil)
+
+// NewPlain returns a Plain renderer writing to 'w'.
+func NewPlain(w io.Writer) *Plain {
+	return &Plain{w: w}
+}
+
+// Render implements Renderer.
+func (p *Plain) Render(prog *progress.Progress, _ bool) error {
+	events := prog.EventsSince(p.last)
+	if len(events) == 0 {
+		return nil
+	}
+	p.last = &events[len(events)-1]
+
+	steps := make(map[string]*progress.Step)
+	for step := range prog.AllSteps() {
+		steps[step.ID] = step
+	}
+	layout := p.TimeFormat
+	if layout == "" {
+		layout = "15:04:05"
+	}
+	for _, event := range events {
+		step := steps[event.StepID]
+		if step == nil || event.From == "" {
+			continue
+		}
+		var lines []string
+		switch {
+		case event.To == progress.StateInProgress:
+			lines = append(lines, title(step)+" started")
+		case event.To.IsTerminal():
+			text := fmt.Sprintf("%s %s", title(step), event.To)
+			if step.StartedAt != nil && !step.StartedAt.After(event.At) {
+				text += fmt.Sprintf(" in %s", event.At.Sub(*step.StartedAt).Round(time.Second))
+			}
+			lines = append(lines, text)
+			if step.State == event.To {
+				for _, log := range failureLogs(step) {
+					lines = append(lines, "  "+log)
+				}
+			}
+		}
+		for _, line := range lines {
+			if _, err := fmt.Fprintf(p.w, "[%s] %s\n", event.At.Format(layout), line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package render_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/render"
+)
+
+func TestPlain(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Start()
+	prog.AddStep("step2")
+
+	var buf bytes.Buffer
+	renderer := render.NewPlain(&buf)
+	renderer.TimeFormat = "T"
+	require.NoError(t, renderer.Render(prog, false))
+	require.Equal(t, "[T] step1 started\n", buf.String())
+
+	// unchanged steps are not printed again
+	buf.Reset()
+	require.NoError(t, renderer.Render(prog, false))
+	require.Empty(t, buf.String())
+
+	prog.Get("step1").Done()
+	prog.Get("step2").Start()
+	prog.Get("step2").Cancel()
+	require.NoError(t, renderer.Render(prog, true))
+	require.Equal(t, []string{
+		"[T] step1 done in 0s",
+		"[T] step2 started",
+		"[T] step2 canceled in 0s",
+	}, strings.Split(strings.TrimSpace(buf.String()), "\n"))
+}
+
+func TestPlain_failureLogs(t *testing.T) {
+	prog := progress.New()
+	step := prog.AddStep("build").Start()
+	step.Logf("compiling")
+	step.Fail(errors.New("exit status 1"))
+
+	var buf bytes.Buffer
+	renderer := render.NewPlain(&buf)
+	renderer.TimeFormat = "T"
+	require.NoError(t, renderer.Render(prog, true))
+	require.Equal(t, []string{
+		"[T] build started",
+		"[T] build failed in 0s",
+		"[T]   compiling",
+	}, strings.Split(strings.TrimSpace(buf.String()), "\n"))
+}
@@ -0,0 +1,105 @@
+// Package render provides terminal renderers drawing a Progress.
+package render // import "moul.io/progress/render"
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"moul.io/progress"
+)
+
+// Renderer draws the state of a Progress.
+type Renderer interface {
+	// Render draws the current state of 'prog'.
+	// 'final' is true for the last call, when the Progress is terminal or when rendering stops.
+	Render(prog *progress.Progress, final bool) error
+}
+
+// OnChange is an interval making Run render a frame only when the Progress changes.
+const OnChange time.Duration = -1
+
+const defaultInterval = 100 * time.Millisecond
+
+// DefaultInterval returns the recommended interval for a renderer writing to 'w':
+// 100ms (10 frames per second) for terminals, and OnChange otherwise to avoid flooding logs.
+func DefaultInterval(w io.Writer) time.Duration {
+	if isTerminal(w) {
+		return defaultInterval
+	}
+	return OnChange
+}
+
+// Run renders frames of 'prog' with 'r' until 'prog' becomes terminal or 'ctx' is done, then renders a final frame.
+//
+// A frame is rendered every 'interval', or each time the Progress changes if 'interval' is OnChange;
+// a zero interval uses 100ms. See DefaultInterval.
+// On unix systems, a frame is also rendered when the terminal is resized.
+// It returns the context error if the context is done first.
+func Run(ctx context.Context, r Renderer, prog *progress.Progress, interval time.Duration) error {
+	var (
+		tick    <-chan time.Time
+		changes chan *progress.Step
+	)
+	switch {
+	case interval < 0:
+		changes = prog.Subscribe()
+		defer prog.Unsubscribe(changes)
+	case interval == 0:
+		interval = defaultInterval
+		fallthrough
+	default:
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	resize, stopResize := notifyResize()
+	defer stopResize()
+
+	if err := r.Render(prog, false); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			if err := r.Render(prog, true); err != nil {
+				return err
+			}
+			return ctx.Err()
+		case <-prog.DoneCh():
+			return r.Render(prog, true)
+		case <-tick:
+			if err := r.Render(prog, false); err != nil {
+				return err
+			}
+		case _, ok := <-changes:
+			if !ok || !drain(changes) {
+				// the subscriber is closed when the Progress is terminal
+				changes = nil
+				continue
+			}
+			if err := r.Render(prog, false); err != nil {
+				return err
+			}
+		case <-resize:
+			if err := r.Render(prog, false); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// drain discards the pending changes, so a single frame is rendered for a burst of changes.
+// It returns false if the chan is closed.
+func drain(changes chan *progress.Step) bool {
+	for {
+		select {
+		case _, ok := <-changes:
+			if !ok {
+				return false
+			}
+		default:
+			return true
+		}
+	}
+}
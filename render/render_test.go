@@ -0,0 +1,69 @@
+package render_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/render"
+)
+
+type countingRenderer struct {
+	mutex  sync.Mutex
+	frames int
+}
+
+func (r *countingRenderer) Render(*progress.Progress, bool) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.frames++
+	return nil
+}
+
+func (r *countingRenderer) count() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.frames
+}
+
+func TestRun_onChange(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1")
+	prog.AddStep("step2")
+
+	renderer := &countingRenderer{}
+	done := make(chan error)
+	go func() { done <- render.Run(context.Background(), renderer, prog, render.OnChange) }()
+
+	// nothing is rendered while the Progress doesn't change
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(t, 1, renderer.count())
+
+	prog.Get("step1").Start()
+	require.Eventually(t, func() bool { return renderer.count() == 2 }, time.Second, time.Millisecond)
+	prog.Get("step1").Done()
+	prog.Get("step2").Done()
+	require.NoError(t, <-done)
+	require.LessOrEqual(t, renderer.count(), 5)
+}
+
+func TestRun_plain(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1")
+
+	var buf bytes.Buffer
+	require.Equal(t, render.OnChange, render.DefaultInterval(&buf))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- render.Run(ctx, render.NewPlain(&buf), prog, render.DefaultInterval(&buf)) }()
+	prog.Get("step1").Start()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	require.Equal(t, context.Canceled, <-done)
+	require.True(t, strings.HasSuffix(buf.String(), "step1 started\n"))
+}
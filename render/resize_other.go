@@ -0,0 +1,10 @@
+//go:build !unix
+
+package render
+
+import "os"
+
+// notifyResize returns a nil chan, terminal resizes are only detected on unix systems.
+func notifyResize() (<-chan os.Signal, func()) {
+	return nil, func() {}
+}
@@ -0,0 +1,155 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"moul.io/progress"
+)
+
+// Steps renders one line per step, redrawing the block in place:
+//
+//	✓ download  3s
+//	⠹ compile   12s  45%
+//	· upload
+//
+// The last lines logged by a failed step are shown below it, see progress.Step.Logf.
+type Steps struct {
+	// Theme colors each line depending on the step state.
+	Theme Theme
+	// MaxWidth is the maximum width of the lines, the titles being ellipsized to fit;
+	// zero uses the terminal width of the writer, a negative value disables the limit.
+	MaxWidth int
+
+	w             io.Writer
+	frame         int
+	block         block
+	percentFormat progress.PercentFormat
+}
+
+var _ Renderer = (*Steps)(nil)
+
+// NewSteps returns a Steps renderer writing to 'w', usually a terminal.
+// Colors are enabled depending on 'w', see AutoTheme.
+func NewSteps(w io.Writer) *Steps {
+	return &Steps{w: w, Theme: AutoTheme(w)}
+}
+
+// Render implements Renderer.
+func (s *Steps) Render(prog *progress.Progress, final bool) error {
+	steps := slices.Collect(prog.AllSteps())
+	s.percentFormat = prog.PercentFormat()
+	lines := s.lines(steps, maxWidth(s.MaxWidth, s.w))
+	s.frame++
+	return s.block.write(s.w, lines, final)
+}
+
+// Lines returns a line per step for the current spinner frame, without any control sequence.
+// The percentages use the PercentFormat of the last rendered Progress.
+// The lines are limited to MaxWidth if positive.
+func (s *Steps) Lines(steps []*progress.Step) []string {
+	limit := s.MaxWidth
+	if limit < 0 {
+		limit = 0
+	}
+	return s.lines(steps, limit)
+}
+
+func (s *Steps) lines(steps []*progress.Step, limit int) []string {
+	titles := make([]string, len(steps))
+	suffixes := make([]string, len(steps))
+	titleWidth, suffixWidth := 0, 0
+	for i, step := range steps {
+		titles[i] = title(step)
+		if width := utf8.RuneCountInString(titles[i]); width > titleWidth {
+			titleWidth = width
+		}
+		if step.StartedAt != nil {
+			suffixes[i] = fmt.Sprintf("  %s", step.Duration().Round(time.Second))
+		}
+		if step.State == progress.StateInProgress && step.Progress > 0 {
+			suffixes[i] += "  " + s.percentFormat.Format(step.Progress, false)
+		}
+		if width := len(suffixes[i]); width > suffixWidth {
+			suffixWidth = width
+		}
+	}
+	// 2 runes for the icon and its space
+	if limit > 0 && titleWidth > limit-2-suffixWidth {
+		titleWidth = limit - 2 - suffixWidth
+		for i := range titles {
+			titles[i] = ellipsize(titles[i], titleWidth)
+		}
+	}
+
+	lines := make([]string, 0, len(steps))
+	for i, step := range steps {
+		var b strings.Builder
+		b.WriteString(s.Theme.paint(step.State, icon(step.State, s.frame)+" "+titles[i]))
+		if suffixes[i] != "" {
+			b.WriteString(strings.Repeat(" ", titleWidth-utf8.RuneCountInString(titles[i])))
+			b.WriteString(suffixes[i])
+		}
+		lines = append(lines, cut(b.String(), limit))
+		for _, log := range failureLogs(step) {
+			lines = append(lines, cut("    "+log, limit))
+		}
+	}
+	return lines
+}
+
+func title(step *progress.Step) string {
+	if step.Description != "" {
+		return step.Description
+	}
+	return step.ID
+}
+
+// failureLogLines is the maximum number of log lines shown below a failed step.
+const failureLogLines = 5
+
+// failureLogs returns the most recent log messages of 'step' if it failed, see Step.Logf.
+func failureLogs(step *progress.Step) []string {
+	if step.State != progress.StateFailed {
+		return nil
+	}
+	logs := step.Logs
+	if len(logs) > failureLogLines {
+		logs = logs[len(logs)-failureLogLines:]
+	}
+	ret := make([]string, 0, len(logs))
+	for _, log := range logs {
+		ret = append(ret, log.Message)
+	}
+	return ret
+}
+
+// block redraws a set of lines in place.
+type block struct {
+	lines int
+}
+
+// write replaces the previously written lines with 'lines'.
+// When 'final' is true, the next call starts a new block below.
+func (b *block) write(w io.Writer, lines []string, final bool) error {
+	var sb strings.Builder
+	if b.lines > 0 {
+		// move the cursor back to the first line of the previous block
+		fmt.Fprintf(&sb, "\x1b[%dA", b.lines)
+	}
+	for _, line := range lines {
+		sb.WriteString("\r")
+		sb.WriteString(line)
+		sb.WriteString("\x1b[K\n")
+	}
+	b.lines = len(lines)
+	if final {
+		b.lines = 0
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
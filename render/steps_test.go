@@ -0,0 +1,48 @@
+package render_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/render"
+)
+
+func TestSteps(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").SetDescription("download").Done()
+	prog.AddStep("step2").Start().SetProgress(0.45)
+	prog.AddStep("step3")
+
+	var buf bytes.Buffer
+	renderer := render.NewSteps(&buf)
+	require.NoError(t, renderer.Render(prog, false))
+	require.Equal(t, ""+
+		"\r✓ download  0s\x1b[K\n"+
+		"\r⠋ step2     0s  45%\x1b[K\n"+
+		"\r· step3\x1b[K\n",
+		buf.String())
+
+	// the block is redrawn in place
+	buf.Reset()
+	prog.Get("step2").Done()
+	require.NoError(t, renderer.Render(prog, true))
+	require.True(t, strings.HasPrefix(buf.String(), "\x1b[3A\r✓ download"))
+	require.Contains(t, buf.String(), "\r✓ step2     0s\x1b[K\n")
+}
+
+func TestSteps_failureLogs(t *testing.T) {
+	prog := progress.New()
+	step := prog.AddStep("build")
+	for i := 1; i <= 7; i++ {
+		step.Logf("line %d", i)
+	}
+	prog.AddStep("test").Logf("not shown")
+	step.Fail(errors.New("exit status 1"))
+
+	lines := render.NewSteps(nil).Lines(prog.Steps)
+	require.Equal(t, []string{"✗ build", "    line 3", "    line 4", "    line 5", "    line 6", "    line 7", "· test"}, lines)
+}
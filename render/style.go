@@ -0,0 +1,84 @@
+package render
+
+import "strings"
+
+// BarStyle defines the runes used to draw a bar.
+type BarStyle struct {
+	// Left and Right delimit the bar.
+	Left, Right string
+	// Full is the rune of a filled cell.
+	Full rune
+	// Partials are the runes of partially filled cells, from the emptiest to the fullest.
+	Partials []rune
+	// Head is drawn after the filled cells until the bar is complete, if no Partials are defined.
+	Head rune
+	// Empty is the rune of an empty cell.
+	Empty rune
+}
+
+var (
+	// StyleDefault draws bars like [=====>    ].
+	StyleDefault = BarStyle{Left: "[", Right: "]", Full: '=', Head: '>', Empty: ' '}
+	// StyleASCII draws bars like [#####     ].
+	StyleASCII = BarStyle{Left: "[", Right: "]", Full: '#', Empty: ' '}
+	// StyleBlocks draws bars with Unicode blocks, with a precision of an eighth of a cell, like │█████▍    │.
+	StyleBlocks = BarStyle{Left: "│", Right: "│", Full: '█', Partials: []rune("▏▎▍▌▋▊▉"), Empty: ' '}
+	// StyleBraille draws bars with braille patterns, like ⣿⣿⣿⣿⣿⣦⣀⣀⣀⣀.
+	StyleBraille = BarStyle{Full: '⣿', Partials: []rune("⣄⣤⣦⣶⣷"), Empty: '⣀'}
+)
+
+// cells returns the filled and the empty parts of a bar of 'width' cells, without the delimiters.
+func (s BarStyle) cells(ratio float64, width int) (string, string) {
+	if s.Full == 0 {
+		s = StyleDefault
+	}
+	if ratio < 0 {
+		ratio = 0
+	}
+	filled := ratio * float64(width)
+	full := int(filled)
+	if full >= width {
+		return strings.Repeat(string(s.Full), width), ""
+	}
+
+	fill := strings.Repeat(string(s.Full), full)
+	switch {
+	case len(s.Partials) > 0:
+		// the partial rune replaces an empty cell only if the cell is at least partially filled
+		if index := int((filled-float64(full))*float64(len(s.Partials)+1)) - 1; index >= 0 {
+			fill += string(s.Partials[index])
+		}
+	case s.Head != 0:
+		fill += string(s.Head)
+	}
+	return fill, strings.Repeat(string(s.Empty), width-len([]rune(fill)))
+}
+
+// Draw returns a bar of 'width' cells representing 'ratio', between 0 and 1, including the delimiters.
+func (s BarStyle) Draw(ratio float64, width int) string {
+	if s.Full == 0 {
+		s = StyleDefault
+	}
+	fill, empty := s.cells(ratio, width)
+	return s.Left + fill + empty + s.Right
+}
+
+// throbber returns the empty cells before and after a block of full cells bouncing across the bar with 'frame',
+// and the block itself, for indeterminate progresses.
+func (s BarStyle) throbber(frame, width int) (string, string, string) {
+	if s.Full == 0 {
+		s = StyleDefault
+	}
+	size := max(width/4, 1)
+	travel := width - size
+	position := 0
+	if travel > 0 {
+		position = frame % (2 * travel)
+		if position > travel {
+			position = 2*travel - position
+		}
+	}
+	return strings.Repeat(string(s.Empty), position),
+		strings.Repeat(string(s.Full), size),
+		strings.Repeat(string(s.Empty), max(travel-position, 0))
+}
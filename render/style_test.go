@@ -0,0 +1,36 @@
+package render_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/render"
+)
+
+func TestBarStyle(t *testing.T) {
+	tests := []struct {
+		style    render.BarStyle
+		ratio    float64
+		expected string
+	}{
+		{render.StyleDefault, 0, "[>         ]"},
+		{render.StyleDefault, 0.5, "[=====>    ]"},
+		{render.StyleDefault, 1, "[==========]"},
+		{render.StyleASCII, 0.55, "[#####     ]"},
+		{render.StyleASCII, 1.5, "[##########]"},
+		{render.StyleBlocks, 0.55, "│█████▌    │"},
+		{render.StyleBlocks, 0.501, "│█████     │"},
+		{render.StyleBraille, 0.55, "⣿⣿⣿⣿⣿⣦⣀⣀⣀⣀"},
+		{render.BarStyle{Left: "(", Right: ")", Full: '*', Empty: '.'}, 0.3, "(***.......)"},
+		{render.BarStyle{}, 0.5, "[=====>    ]"},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, test.style.Draw(test.ratio, 10))
+	}
+
+	bar := render.NewBar(nil)
+	bar.Width = 4
+	bar.Style = render.StyleASCII
+	require.Equal(t, "[##  ]  50%", bar.Line(progress.Snapshot{Progress: 0.5}))
+}
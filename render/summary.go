@@ -0,0 +1,56 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"moul.io/progress"
+)
+
+// PrintSummary writes a human-readable summary to 'w', usually once the Progress is terminal:
+//
+//	✓ done: 3/3 steps in 1m2s
+//	slowest steps:
+//	  compile  40s
+//	  test     20s
+func PrintSummary(w io.Writer, summary progress.Summary) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s: %d/%d steps", icon(summary.State, 0), summary.State, summary.Completed, summary.Total)
+	if summary.Duration > 0 {
+		fmt.Fprintf(&b, " in %s", summary.Duration.Round(time.Millisecond))
+	}
+	b.WriteString("\n")
+
+	if len(summary.Slowest) > 0 {
+		b.WriteString("slowest steps:\n")
+		width := 0
+		for _, step := range summary.Slowest {
+			if n := utf8.RuneCountInString(title(step)); n > width {
+				width = n
+			}
+		}
+		for _, step := range summary.Slowest {
+			fmt.Fprintf(&b, "  %s%s  %s\n", title(step), strings.Repeat(" ", width-utf8.RuneCountInString(title(step))), step.Duration().Round(time.Millisecond))
+		}
+	}
+	for _, list := range []struct {
+		name string
+		ids  []string
+	}{
+		{"failed", summary.Failed},
+		{"canceled", summary.Canceled},
+		{"skipped", summary.Skipped},
+		{"interrupted", summary.Interrupted},
+		{"not started", summary.NotStarted},
+	} {
+		if len(list.ids) > 0 {
+			fmt.Fprintf(&b, "%s: %s\n", list.name, strings.Join(list.ids, ", "))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,39 @@
+package render_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/render"
+)
+
+func TestPrintSummary(t *testing.T) {
+	t0 := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	t1, t2 := t0.Add(40*time.Second), t0.Add(time.Minute)
+	summary := progress.Summary{
+		State:     progress.StateCanceled,
+		Total:     4,
+		Completed: 2,
+		Duration:  time.Minute,
+		Slowest: []*progress.Step{
+			{ID: "compile", State: progress.StateDone, StartedAt: &t0, DoneAt: &t1},
+			{ID: "test", State: progress.StateDone, StartedAt: &t1, DoneAt: &t2},
+		},
+		Canceled:   []string{"deploy"},
+		NotStarted: []string{"notify"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, render.PrintSummary(&buf, summary))
+	require.Equal(t, ""+
+		"✗ canceled: 2/4 steps in 1m0s\n"+
+		"slowest steps:\n"+
+		"  compile  40s\n"+
+		"  test     20s\n"+
+		"canceled: deploy\n"+
+		"not started: notify\n",
+		buf.String())
+}
@@ -0,0 +1,95 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"moul.io/progress"
+)
+
+// TemplateData is the data passed to the templates of a Template renderer.
+type TemplateData struct {
+	Snapshot progress.Snapshot
+	Steps    []*progress.Step
+}
+
+// Template renders a user-defined text/template, redrawn in place.
+//
+// In addition to the standard functions, templates can use:
+//
+//	percent SNAPSHOT        formats the completion of a snapshot as a percentage, e.g. "45%", see
+//	                        progress.Snapshot.FormatPercent; a ratio between 0 and 1 is also accepted.
+//	bar RATIO WIDTH         draws a bar of WIDTH cells, e.g. "====>     ".
+//	humanDuration DURATION  formats a duration rounded to the second, e.g. "1m12s".
+//	stateIcon STATE         returns the icon of a state, animated for in-progress steps.
+//
+// For instance:
+//
+//	{{bar .Snapshot.Progress 20}} {{percent .Snapshot}} {{.Snapshot.Doing}}
+type Template struct {
+	// MaxWidth is the maximum width of the lines, longer lines being ellipsized;
+	// zero uses the terminal width of the writer, a negative value disables the limit.
+	MaxWidth int
+
+	w     io.Writer
+	tmpl  *template.Template
+	frame int
+	block block
+}
+
+var _ Renderer = (*Template)(nil)
+
+// NewTemplate returns a Template renderer writing to 'w' the result of the 'text' template.
+func NewTemplate(w io.Writer, text string) (*Template, error) {
+	t := &Template{w: w}
+	tmpl, err := template.New("progress").Funcs(template.FuncMap{
+		"percent":       templatePercent,
+		"bar":           templateBar,
+		"humanDuration": func(d time.Duration) string { return d.Round(time.Second).String() },
+		"stateIcon":     func(state progress.State) string { return icon(state, t.frame) },
+	}).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	t.tmpl = tmpl
+	return t, nil
+}
+
+// Render implements Renderer.
+func (t *Template) Render(prog *progress.Progress, final bool) error {
+	steps := slices.Collect(prog.AllSteps())
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, TemplateData{Snapshot: prog.Snapshot(), Steps: steps}); err != nil {
+		return err
+	}
+	t.frame++
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	lines = cutLines(lines, maxWidth(t.MaxWidth, t.w))
+	return t.block.write(t.w, lines, final)
+}
+
+// Execute writes the template applied to 'data' to 'w', without any control sequence.
+func (t *Template) Execute(w io.Writer, data TemplateData) error {
+	return t.tmpl.Execute(w, data)
+}
+
+func templatePercent(value interface{}) (string, error) {
+	switch typed := value.(type) {
+	case progress.Snapshot:
+		return typed.FormatPercent(), nil
+	case float64:
+		return progress.PercentFormat{}.Format(typed, typed >= 1), nil
+	default:
+		return "", fmt.Errorf("percent: unsupported type %T", value)
+	}
+}
+
+func templateBar(ratio float64, width int) string {
+	fill, empty := StyleDefault.cells(ratio, width)
+	return fill + empty
+}
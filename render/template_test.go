@@ -0,0 +1,63 @@
+package render_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/render"
+)
+
+func TestTemplate(t *testing.T) {
+	tmpl, err := render.NewTemplate(nil, `[{{bar .Snapshot.Progress 10}}] {{percent .Snapshot.Progress}} {{humanDuration .Snapshot.TotalDuration}}
+{{- range .Steps}}
+{{stateIcon .State}} {{.ID}}
+{{- end}}`)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, render.TemplateData{
+		Snapshot: progress.Snapshot{Progress: 0.5, TotalDuration: 72500 * time.Millisecond},
+		Steps: []*progress.Step{
+			{ID: "step1", State: progress.StateDone},
+			{ID: "step2", State: progress.StateInProgress},
+		},
+	}))
+	require.Equal(t, "[=====>    ] 50% 1m13s\n✓ step1\n⠋ step2", buf.String())
+
+	_, err = render.NewTemplate(nil, "{{unknown}}")
+	require.Error(t, err)
+}
+
+func TestTemplate_almostDone(t *testing.T) {
+	prog := progress.New(progress.WithPercentFormat(progress.PercentFormat{Rounding: progress.PercentRound}))
+	for i := 0; i < 199; i++ {
+		prog.AddStep(fmt.Sprintf("step%d", i)).Done()
+	}
+	prog.AddStep("last").Start()
+
+	tmpl, err := render.NewTemplate(nil, "{{percent .Snapshot}}")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, render.TemplateData{Snapshot: prog.Snapshot()}))
+	require.Equal(t, "99%", buf.String())
+
+	prog.Get("last").Done()
+	buf.Reset()
+	require.NoError(t, tmpl.Execute(&buf, render.TemplateData{Snapshot: prog.Snapshot()}))
+	require.Equal(t, "100%", buf.String())
+}
+
+func TestTemplate_render(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Done()
+
+	var buf bytes.Buffer
+	tmpl, err := render.NewTemplate(&buf, "{{percent .Snapshot.Progress}}\n")
+	require.NoError(t, err)
+	require.NoError(t, tmpl.Render(prog, true))
+	require.Equal(t, "\r100%\x1b[K\n", buf.String())
+}
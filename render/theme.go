@@ -0,0 +1,72 @@
+package render
+
+import (
+	"io"
+	"os"
+
+	"moul.io/progress"
+)
+
+// Theme maps states to the ANSI escape sequence used to color them; a nil Theme disables colors.
+type Theme map[progress.State]string
+
+// DefaultTheme colors in-progress steps in cyan, done steps in green, canceled and failed steps in red,
+// and not-started and skipped steps in grey.
+var DefaultTheme = Theme{
+	progress.StateNotStarted:  "\x1b[90m",
+	progress.StateInProgress:  "\x1b[36m",
+	progress.StateDone:        "\x1b[32m",
+	progress.StateCanceled:    "\x1b[31m",
+	progress.StateFailed:      "\x1b[31m",
+	progress.StateSkipped:     "\x1b[90m",
+	progress.StateInterrupted: "\x1b[33m",
+	progress.StateStopped:     "\x1b[33m",
+}
+
+const ansiReset = "\x1b[0m"
+
+// AutoTheme returns DefaultTheme if ColorEnabled reports colors should be used for 'w', else nil.
+func AutoTheme(w io.Writer) Theme {
+	if ColorEnabled(w) {
+		return DefaultTheme
+	}
+	return nil
+}
+
+// ColorEnabled reports whether colors should be written to 'w'.
+//
+// NO_COLOR disables colors, CLICOLOR_FORCE enables them, and CLICOLOR=0 disables them;
+// otherwise colors are enabled only if 'w' is a terminal.
+func ColorEnabled(w io.Writer) bool {
+	if _, found := os.LookupEnv("NO_COLOR"); found {
+		return false
+	}
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true
+	}
+	if os.Getenv("CLICOLOR") == "0" {
+		return false
+	}
+	return isTerminal(w)
+}
+
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// paint wraps 's' with the color of 'state'.
+func (t Theme) paint(state progress.State, s string) string {
+	color, found := t[state]
+	if !found || color == "" || s == "" {
+		return s
+	}
+	return color + s + ansiReset
+}
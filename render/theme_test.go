@@ -0,0 +1,50 @@
+package render_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/render"
+)
+
+func TestColorEnabled(t *testing.T) {
+	var buf bytes.Buffer
+	t.Setenv("NO_COLOR", "") // restores the original value on cleanup
+	os.Unsetenv("NO_COLOR")
+	t.Setenv("CLICOLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "")
+	require.False(t, render.ColorEnabled(&buf))
+	require.Nil(t, render.AutoTheme(&buf))
+
+	t.Setenv("CLICOLOR_FORCE", "1")
+	require.True(t, render.ColorEnabled(&buf))
+
+	t.Setenv("NO_COLOR", "")
+	require.False(t, render.ColorEnabled(&buf))
+}
+
+func TestTheme(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Done()
+	prog.AddStep("step2")
+
+	renderer := render.NewSteps(nil)
+	renderer.Theme = render.DefaultTheme
+	steps := []*progress.Step{prog.Get("step1"), prog.Get("step2")}
+	require.Equal(t, []string{
+		"\x1b[32m✓ step1\x1b[0m  0s",
+		"\x1b[90m· step2\x1b[0m",
+	}, renderer.Lines(steps))
+
+	bar := render.NewBar(nil)
+	bar.Width = 4
+	bar.Theme = render.Theme{progress.StateInProgress: "\x1b[36m"}
+	require.Equal(t, "[\x1b[36m==>\x1b[0m ]  50% \x1b[36m⠋\x1b[0m step1", bar.Line(progress.Snapshot{
+		State:    progress.StateInProgress,
+		Progress: 0.5,
+		Doing:    "step1",
+	}))
+}
@@ -0,0 +1,161 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"moul.io/progress"
+)
+
+// Tree renders steps as a tree, using the step IDs as paths:
+// "build/compile" and "build/test" are rendered as children of a "build" node.
+//
+//	build  50%
+//	├─ ✓ compile  3s
+//	└─ ⠹ test  12s
+//	· deploy
+//
+// Group nodes show the completion of their descendants. The block is redrawn in place.
+type Tree struct {
+	// Separator splits the step IDs into paths, "/" if empty.
+	Separator string
+	// MaxWidth is the maximum width of the lines, longer lines being ellipsized;
+	// zero uses the terminal width of the writer, a negative value disables the limit.
+	MaxWidth int
+	// Theme colors the steps depending on their state.
+	Theme Theme
+
+	w             io.Writer
+	frame         int
+	block         block
+	percentFormat progress.PercentFormat
+}
+
+var _ Renderer = (*Tree)(nil)
+
+// NewTree returns a Tree renderer writing to 'w'.
+// Colors are enabled depending on 'w', see AutoTheme.
+func NewTree(w io.Writer) *Tree {
+	return &Tree{w: w, Theme: AutoTheme(w)}
+}
+
+// Render implements Renderer.
+func (t *Tree) Render(prog *progress.Progress, final bool) error {
+	steps := slices.Collect(prog.AllSteps())
+	t.percentFormat = prog.PercentFormat()
+	lines := cutLines(t.Lines(steps), maxWidth(t.MaxWidth, t.w))
+	t.frame++
+	return t.block.write(t.w, lines, final)
+}
+
+type treeNode struct {
+	name     string
+	step     *progress.Step
+	children []*treeNode
+}
+
+func (n *treeNode) child(name string) *treeNode {
+	for _, child := range n.children {
+		if child.name == name {
+			return child
+		}
+	}
+	child := &treeNode{name: name}
+	n.children = append(n.children, child)
+	return child
+}
+
+// ratio returns the completion of the node and the number of steps it contains.
+func (n *treeNode) ratio() (float64, int) {
+	var (
+		sum   float64
+		count int
+	)
+	if n.step != nil {
+		switch n.step.State {
+		case progress.StateDone:
+			sum++
+		case progress.StateInProgress:
+			sum += n.step.Progress
+		}
+		count++
+	}
+	for _, child := range n.children {
+		childRatio, childCount := child.ratio()
+		sum += childRatio * float64(childCount)
+		count += childCount
+	}
+	if count == 0 {
+		return 0, 0
+	}
+	return sum / float64(count), count
+}
+
+// isTerminal returns true if the steps of the node and of its descendants are terminal.
+func (n *treeNode) isTerminal() bool {
+	if n.step != nil && !n.step.State.IsTerminal() {
+		return false
+	}
+	for _, child := range n.children {
+		if !child.isTerminal() {
+			return false
+		}
+	}
+	return true
+}
+
+// Lines returns the tree lines of 'steps' for the current spinner frame, without any control sequence.
+// The percentages use the PercentFormat of the last rendered Progress.
+func (t *Tree) Lines(steps []*progress.Step) []string {
+	separator := t.Separator
+	if separator == "" {
+		separator = "/"
+	}
+	root := &treeNode{}
+	for _, step := range steps {
+		node := root
+		for _, name := range strings.Split(step.ID, separator) {
+			node = node.child(name)
+		}
+		node.step = step
+	}
+
+	var lines []string
+	var walk func(node *treeNode, prefix, childPrefix string)
+	walk = func(node *treeNode, prefix, childPrefix string) {
+		var b strings.Builder
+		b.WriteString(prefix)
+		if node.step != nil {
+			name := node.name
+			if node.step.Description != "" {
+				name = node.step.Description
+			}
+			b.WriteString(t.Theme.paint(node.step.State, icon(node.step.State, t.frame)+" "+name))
+			if node.step.StartedAt != nil {
+				fmt.Fprintf(&b, "  %s", node.step.Duration().Round(time.Second))
+			}
+		} else {
+			b.WriteString(node.name)
+		}
+		if len(node.children) > 0 || (node.step != nil && node.step.State == progress.StateInProgress && node.step.Progress > 0) {
+			ratio, _ := node.ratio()
+			b.WriteString("  " + t.percentFormat.Format(ratio, node.isTerminal()))
+		}
+		lines = append(lines, b.String())
+
+		for i, child := range node.children {
+			if i == len(node.children)-1 {
+				walk(child, childPrefix+"└─ ", childPrefix+"   ")
+			} else {
+				walk(child, childPrefix+"├─ ", childPrefix+"│  ")
+			}
+		}
+	}
+	for _, node := range root.children {
+		walk(node, "", "")
+	}
+	return lines
+}
@@ -0,0 +1,33 @@
+package render_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/render"
+)
+
+func TestTree(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("build/compile").Done()
+	prog.AddStep("build/test/unit").Start().SetProgress(0.5)
+	prog.AddStep("build/test/e2e")
+	prog.AddStep("deploy")
+
+	tree := render.NewTree(nil)
+	steps := []*progress.Step{
+		prog.Get("build/compile"),
+		prog.Get("build/test/unit"),
+		prog.Get("build/test/e2e"),
+		prog.Get("deploy"),
+	}
+	require.Equal(t, []string{
+		"build  50%",
+		"├─ ✓ compile  0s",
+		"└─ test  25%",
+		"   ├─ ⠋ unit  0s  50%",
+		"   └─ · e2e",
+		"· deploy",
+	}, tree.Lines(steps))
+}
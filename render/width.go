@@ -0,0 +1,110 @@
+package render
+
+import (
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+const ellipsis = "…"
+
+// TerminalWidth returns the number of columns of the terminal behind 'w', or 0 if 'w' is not a terminal.
+func TerminalWidth(w io.Writer) int {
+	file, ok := w.(*os.File)
+	if !ok {
+		return 0
+	}
+	width, _, err := term.GetSize(int(file.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// maxWidth returns 'configured' if set, else the terminal width of 'w'.
+// A negative configured value disables the limit.
+func maxWidth(configured int, w io.Writer) int {
+	switch {
+	case configured > 0:
+		return configured
+	case configured < 0:
+		return 0
+	default:
+		return TerminalWidth(w)
+	}
+}
+
+// ellipsize shortens 's' to 'width' runes, replacing the end with an ellipsis.
+func ellipsize(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + ellipsis
+}
+
+// visibleLen returns the number of runes of 's', ignoring ANSI escape sequences.
+func visibleLen(s string) int {
+	n := 0
+	inEscape := false
+	for _, r := range s {
+		switch {
+		case inEscape:
+			inEscape = !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+		case r == '\x1b':
+			inEscape = true
+		default:
+			n++
+		}
+	}
+	return n
+}
+
+// cut shortens 's' to 'width' visible runes, keeping ANSI escape sequences intact.
+func cut(s string, width int) string {
+	if width <= 0 || visibleLen(s) <= width {
+		return s
+	}
+	var (
+		b        strings.Builder
+		n        int
+		inEscape bool
+		colored  bool
+	)
+	for _, r := range s {
+		switch {
+		case inEscape:
+			inEscape = !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+			b.WriteRune(r)
+			continue
+		case r == '\x1b':
+			inEscape = true
+			colored = true
+			b.WriteRune(r)
+			continue
+		}
+		if n == width-1 {
+			break
+		}
+		b.WriteRune(r)
+		n++
+	}
+	b.WriteString(ellipsis)
+	if colored {
+		b.WriteString(ansiReset)
+	}
+	return b.String()
+}
+
+func cutLines(lines []string, width int) []string {
+	for i, line := range lines {
+		lines[i] = cut(line, width)
+	}
+	return lines
+}
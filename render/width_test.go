@@ -0,0 +1,43 @@
+package render_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/render"
+)
+
+func TestMaxWidth(t *testing.T) {
+	bar := render.NewBar(nil)
+	bar.Width = 4
+	bar.MaxWidth = 20
+	require.Equal(t, "[==> ]  50% ⠋ step1…", bar.Line(progress.Snapshot{
+		State:    progress.StateInProgress,
+		Progress: 0.5,
+		Doing:    "step1, step2",
+	}))
+
+	prog := progress.New()
+	prog.AddStep("step1").SetDescription("a very long description").Done()
+	prog.AddStep("step2")
+	steps := render.NewSteps(nil)
+	steps.MaxWidth = 16
+	require.Equal(t, []string{
+		"✓ a very lo…  0s",
+		"· step2",
+	}, steps.Lines([]*progress.Step{prog.Get("step1"), prog.Get("step2")}))
+
+	// colored lines are cut without breaking the escape sequences
+	var buf bytes.Buffer
+	tree := render.NewTree(&buf)
+	tree.MaxWidth = 8
+	tree.Theme = render.DefaultTheme
+	require.NoError(t, tree.Render(prog, true))
+	require.Equal(t, "\r\x1b[32m✓ a ver…\x1b[0m\x1b[K\n\r\x1b[90m· step2\x1b[0m\x1b[K\n", buf.String())
+}
+
+func TestTerminalWidth(t *testing.T) {
+	require.Equal(t, 0, render.TerminalWidth(&bytes.Buffer{}))
+}
@@ -0,0 +1,95 @@
+package report
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"moul.io/progress"
+)
+
+var htmlTemplates = template.Must(template.New("").Funcs(template.FuncMap{
+	"title":    title,
+	"duration": duration,
+	"class":    func(state progress.State) string { return strings.ReplaceAll(string(state), " ", "-") },
+	"round":    func(d time.Duration) time.Duration { return d.Round(time.Millisecond) },
+}).Parse(`
+{{- define "fragment" -}}
+<div class="progress-report">
+<div class="progress-summary">{{.Snapshot.State}} — {{.Snapshot.Completed}}/{{.Snapshot.Total}} steps done ({{.Snapshot.FormatPercent}}){{if .Snapshot.TotalDuration}} in {{round .Snapshot.TotalDuration}}{{end}}</div>
+<div class="progress-bar" style="background:#eee;border-radius:4px;overflow:hidden"><div style="width:{{.Snapshot.FormatPercent}};background:#4caf50;height:1em"></div></div>
+<table class="progress-steps">
+<thead><tr><th>Step</th><th>State</th><th>Duration</th></tr></thead>
+<tbody>
+{{- range .Steps}}
+<tr class="progress-step-{{class .State}}"><td>{{title .}}</td><td>{{.State}}</td><td>{{duration .}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+</div>
+{{- end -}}
+{{- define "page" -}}
+<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+{{- if .Refresh}}
+<meta http-equiv="refresh" content="{{.Refresh}}">
+{{- end}}
+<title>Progress report</title>
+<style>body{font-family:sans-serif;margin:2em}table{border-collapse:collapse;margin-top:1em}th,td{padding:.3em 1em;text-align:left;border-bottom:1px solid #ddd}</style>
+</head>
+<body>
+{{template "fragment" .}}
+</body>
+</html>
+{{end -}}
+`))
+
+type htmlData struct {
+	data
+	Refresh int
+}
+
+// HTML returns a standalone HTML page with a progress bar, a table of the steps, and the timings of 'prog'.
+func HTML(prog *progress.Progress) (string, error) {
+	return renderHTML("page", prog, 0)
+}
+
+// HTMLFragment returns the content of the HTML page, without the document structure, to embed it in another page.
+func HTMLFragment(prog *progress.Progress) (string, error) {
+	return renderHTML("fragment", prog, 0)
+}
+
+// HTMLHandler returns an http.Handler serving the HTML page of 'prog'.
+// While the Progress is running, the page refreshes itself every 'refresh'.
+func HTMLHandler(prog *progress.Progress, refresh time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seconds := int(refresh.Seconds())
+		if seconds < 1 {
+			seconds = 1
+		}
+		if prog.Snapshot().State.IsTerminal() {
+			seconds = 0
+		}
+		page, err := renderHTML("page", prog, seconds)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache")
+		_, _ = w.Write([]byte(page))
+	})
+}
+
+func renderHTML(name string, prog *progress.Progress, refresh int) (string, error) {
+	d := load(prog)
+	var buf bytes.Buffer
+	if err := htmlTemplates.ExecuteTemplate(&buf, name, htmlData{data: d, Refresh: refresh}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
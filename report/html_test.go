@@ -0,0 +1,48 @@
+package report_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/report"
+)
+
+func TestHTML(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").SetDescription("<build>").Done()
+	prog.AddStep("step2").Start()
+
+	page, err := report.HTML(prog)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(page, "<!DOCTYPE html>"))
+	require.Contains(t, page, "<td>&lt;build&gt;</td><td>done</td>")
+	require.Contains(t, page, `<div style="width:75%;`)
+	require.NotContains(t, page, "refresh")
+
+	fragment, err := report.HTMLFragment(prog)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(fragment, `<div class="progress-report">`))
+	require.Contains(t, fragment, `<tr class="progress-step-in-progress"><td>step2</td>`)
+}
+
+func TestHTMLHandler(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Start()
+	handler := report.HTMLHandler(prog, 5*time.Second)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	require.Contains(t, rec.Body.String(), `<meta http-equiv="refresh" content="5">`)
+
+	// no more refresh once the Progress is terminal
+	prog.Get("step1").Done()
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NotContains(t, rec.Body.String(), "refresh")
+}
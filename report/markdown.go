@@ -0,0 +1,56 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"moul.io/progress"
+)
+
+var markdownEmojis = map[progress.State]string{
+	progress.StateNotStarted:  "⏸️",
+	progress.StateInProgress:  "⏳",
+	progress.StateDone:        "✅",
+	progress.StateCanceled:    "❌",
+	progress.StateFailed:      "💥",
+	progress.StateSkipped:     "⏭️",
+	progress.StateInterrupted: "⚠️",
+	progress.StateStopped:     "⏹️",
+}
+
+// Markdown returns a Markdown document with a summary table of the steps and the totals of 'prog'.
+func Markdown(prog *progress.Progress) (string, error) {
+	d := load(prog)
+
+	var b strings.Builder
+	b.WriteString("| Step | State | Duration |\n")
+	b.WriteString("| --- | --- | --: |\n")
+	for _, step := range d.Steps {
+		fmt.Fprintf(&b, "| %s | %s %s | %s |\n",
+			markdownEscape(title(step)), markdownEmojis[step.State], step.State, duration(step))
+	}
+
+	s := d.Snapshot
+	fmt.Fprintf(&b, "\n**%s %s** — %d/%d steps done (%s)", markdownEmojis[s.State], s.State, s.Completed, s.Total, s.FormatPercent())
+	if s.Failed > 0 {
+		fmt.Fprintf(&b, ", %d failed", s.Failed)
+	}
+	if s.Canceled > 0 {
+		fmt.Fprintf(&b, ", %d canceled", s.Canceled)
+	}
+	if s.Skipped > 0 {
+		fmt.Fprintf(&b, ", %d skipped", s.Skipped)
+	}
+	if s.TotalDuration > 0 {
+		fmt.Fprintf(&b, " in %s", s.TotalDuration.Round(time.Millisecond))
+	}
+	b.WriteString("\n")
+	return b.String(), nil
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\n", " ")
+
+func markdownEscape(s string) string {
+	return markdownEscaper.Replace(s)
+}
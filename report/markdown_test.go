@@ -0,0 +1,28 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/report"
+)
+
+func TestMarkdown(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").SetDescription("build | test").Done()
+	prog.AddStep("step2").Cancel()
+	prog.AddStep("step3")
+
+	md, err := report.Markdown(prog)
+	require.NoError(t, err)
+	require.Equal(t, ""+
+		"| Step | State | Duration |\n"+
+		"| --- | --- | --: |\n"+
+		"| build \\| test | ✅ done | 0s |\n"+
+		"| step2 | ❌ canceled |  |\n"+
+		"| step3 | ⏸️ not started |  |\n"+
+		"\n"+
+		"**⏹️ stopped** — 1/3 steps done (33%), 1 canceled in 0s\n",
+		md)
+}
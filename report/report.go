@@ -0,0 +1,33 @@
+// Package report generates documents summarizing a Progress, e.g. to attach to pull requests or job summaries.
+package report // import "moul.io/progress/report"
+
+import (
+	"slices"
+	"time"
+
+	"moul.io/progress"
+)
+
+// data is a consistent view of a Progress used by the generators.
+type data struct {
+	Snapshot progress.Snapshot
+	Steps    []*progress.Step
+}
+
+func load(prog *progress.Progress) data {
+	return data{Snapshot: prog.Snapshot(), Steps: slices.Collect(prog.AllSteps())}
+}
+
+func title(step *progress.Step) string {
+	if step.Description != "" {
+		return step.Description
+	}
+	return step.ID
+}
+
+func duration(step *progress.Step) string {
+	if step.StartedAt == nil {
+		return ""
+	}
+	return step.Duration().Round(time.Millisecond).String()
+}
@@ -0,0 +1,55 @@
+package progress
+
+import "time"
+
+// RetryPolicy configures how Progress.Run retries a failing step function.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of executions, including the first one; retries are disabled below 2.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled after each retry.
+	Backoff time.Duration
+	// MaxBackoff caps the delay between retries if positive.
+	MaxBackoff time.Duration
+	// Retryable reports whether an error should be retried; every error is retried if nil.
+	Retryable func(err error) bool
+}
+
+// Attempt is an execution of a step function by Progress.Run, recorded when the step has a RetryPolicy.
+type Attempt struct {
+	StartedAt time.Time `json:"started_at" yaml:"started_at"`
+	DoneAt    time.Time `json:"done_at" yaml:"done_at"`
+	Error     string    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// SetRetry sets the policy used by Progress.Run to retry the step function when it fails.
+// Each attempt is then recorded in Step.Attempts.
+// It returns itself (*Step) for chaining.
+func (s *Step) SetRetry(policy RetryPolicy) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	s.retry = &policy
+	return s
+}
+
+// shouldRetry returns true if another attempt is allowed after the 'attempt'-th one failed with 'err'.
+func (policy *RetryPolicy) shouldRetry(attempt int, err error) bool {
+	if policy == nil || attempt >= policy.MaxAttempts {
+		return false
+	}
+	return policy.Retryable == nil || policy.Retryable(err)
+}
+
+// delay returns the backoff before the retry following the 'attempt'-th attempt.
+func (policy *RetryPolicy) delay(attempt int) time.Duration {
+	delay := policy.Backoff
+	for i := 1; i < attempt && delay > 0; i++ {
+		delay *= 2
+		if policy.MaxBackoff > 0 && delay >= policy.MaxBackoff {
+			break
+		}
+	}
+	if policy.MaxBackoff > 0 && delay > policy.MaxBackoff {
+		delay = policy.MaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,73 @@
+package progress_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestRetry(t *testing.T) {
+	prog := progress.New()
+	calls := 0
+	prog.AddStep("flaky").SetRetry(progress.RetryPolicy{MaxAttempts: 3, Backoff: 10 * time.Millisecond}).
+		SetFunc(func(context.Context, *progress.Step) error {
+			calls++
+			if calls < 3 {
+				return errors.New("temporary")
+			}
+			return nil
+		})
+
+	before := time.Now()
+	require.NoError(t, prog.Run(context.Background()))
+	require.GreaterOrEqual(t, time.Since(before), 30*time.Millisecond) // 10ms + 20ms
+	step := prog.Get("flaky")
+	require.Equal(t, progress.StateDone, step.State)
+	require.Empty(t, step.Error)
+	require.Len(t, step.Attempts, 3)
+	require.Equal(t, "temporary", step.Attempts[0].Error)
+	require.Equal(t, "temporary", step.Attempts[1].Error)
+	require.Empty(t, step.Attempts[2].Error)
+	require.False(t, step.Attempts[1].StartedAt.Before(step.Attempts[0].DoneAt))
+}
+
+func TestRetry_exhausted(t *testing.T) {
+	prog := progress.New()
+	errFatal := errors.New("fatal")
+	calls := map[string]int{}
+	fail := func(err error) progress.StepFunc {
+		return func(_ context.Context, step *progress.Step) error {
+			calls[step.ID]++
+			return err
+		}
+	}
+	policy := progress.RetryPolicy{
+		MaxAttempts: 2,
+		Retryable:   func(err error) bool { return !errors.Is(err, errFatal) },
+	}
+	prog.AddStep("temporary").SetRetry(policy).SetFunc(fail(errors.New("temporary")))
+	prog.AddStep("fatal").SetRetry(policy).SetFunc(fail(errFatal))
+
+	require.Error(t, prog.Run(context.Background(), progress.KeepGoing()))
+	require.Equal(t, map[string]int{"temporary": 2, "fatal": 1}, calls)
+	require.Equal(t, progress.StateFailed, prog.Get("temporary").State)
+	require.Len(t, prog.Get("temporary").Attempts, 2)
+	require.Len(t, prog.Get("fatal").Attempts, 1)
+}
+
+func TestRetry_canceled(t *testing.T) {
+	prog := progress.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	prog.AddStep("step").SetRetry(progress.RetryPolicy{MaxAttempts: 5, Backoff: time.Hour}).
+		SetFunc(func(context.Context, *progress.Step) error {
+			cancel()
+			return errors.New("temporary")
+		})
+	require.Error(t, prog.Run(ctx))
+	require.Equal(t, progress.StateCanceled, prog.Get("step").State)
+	require.Len(t, prog.Get("step").Attempts, 1)
+}
@@ -0,0 +1,385 @@
+package progress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// StepFunc is the function executed by Progress.Run for a step.
+//
+// It receives its step, so it can report its own progress while running, i.e., with SetProgress (below 1),
+// AddUnits, SetDescription, or SetData; the step is also available from 'ctx' with StepFromContext.
+type StepFunc func(ctx context.Context, step *Step) error
+
+// SetFunc attaches the function executed for the step by Progress.Run.
+// It returns itself (*Step) for chaining.
+func (s *Step) SetFunc(fn StepFunc) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	s.fn = fn
+	return s
+}
+
+// DependsOn declares that the step can only be executed by Progress.Run once the steps matching 'ids'
+// are done.
+// It returns itself (*Step) for chaining.
+func (s *Step) DependsOn(ids ...string) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	s.dependencies = append(s.dependencies, ids...)
+	return s
+}
+
+// Dependencies returns the IDs of the steps declared with DependsOn.
+func (s *Step) Dependencies() []string {
+	s.parent.mainMutex.RLock()
+	defer s.parent.mainMutex.RUnlock()
+	return append([]string(nil), s.dependencies...)
+}
+
+// SetPriority sets the priority of the step: when more steps are ready than Progress.Run can execute,
+// the steps with the highest priority are started first. The default priority is 0.
+// It returns itself (*Step) for chaining.
+func (s *Step) SetPriority(priority int) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	s.priority = priority
+	return s
+}
+
+// SkipIf makes Progress.Run skip the step with 'reason' if 'cond' returns true when the step is about to be
+// executed, see Step.Skip.
+// It returns itself (*Step) for chaining.
+func (s *Step) SkipIf(reason string, cond func() bool) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	s.skipIf, s.skipReason = cond, reason
+	return s
+}
+
+// RunOption configures Progress.Run.
+type RunOption func(*runConfig)
+
+type runConfig struct {
+	concurrency int
+	keepGoing   bool
+	middlewares []Middleware
+	groupLimits map[string]int
+}
+
+// Middleware wraps the execution of step functions by Progress.Run, i.e., for logging or tracing.
+type Middleware func(next StepFunc) StepFunc
+
+// WithMiddleware wraps every step function executed by Progress.Run with 'middlewares';
+// the first middleware is the outermost one. Middlewares wrap each attempt of a retried step.
+func WithMiddleware(middlewares ...Middleware) RunOption {
+	return func(config *runConfig) { config.middlewares = append(config.middlewares, middlewares...) }
+}
+
+// WithConcurrency sets the maximum number of step functions executed in parallel by Progress.Run.
+// The default is 1, executing the steps one after the other; values below 1 remove the limit.
+func WithConcurrency(n int) RunOption {
+	return func(config *runConfig) { config.concurrency = n }
+}
+
+// WithGroupLimit sets the maximum number of steps of 'group' executed in parallel by Progress.Run,
+// in addition to the global WithConcurrency limit. Values below 1 remove the limit. See Step.SetGroup.
+func WithGroupLimit(group string, n int) RunOption {
+	return func(config *runConfig) {
+		if config.groupLimits == nil {
+			config.groupLimits = make(map[string]int)
+		}
+		config.groupLimits[group] = n
+	}
+}
+
+// KeepGoing makes Progress.Run execute the steps not depending on a failed step instead of stopping
+// at the first failure; every error is then returned, joined.
+func KeepGoing() RunOption {
+	return func(config *runConfig) { config.keepGoing = true }
+}
+
+// Run executes the functions attached to the steps with SetFunc.
+//
+// Steps are executed by priority then in order, see Step.SetPriority; a step declaring dependencies with
+// DependsOn is executed only once they are all done or skipped.
+// Independent steps run in parallel up to WithConcurrency and WithGroupLimit.
+// Dependencies not executed by Run, i.e., without function, must be done or skipped before calling Run.
+// Steps whose SkipIf condition is met are skipped instead of executed.
+//
+// Each step is started before calling its function, then marked as done if the function returns nil,
+// or as failed with the returned error; the function may also terminate its step by itself.
+// A panicking function is handled as a failure returning a *PanicError.
+// A failing function is executed again according to the RetryPolicy of its step, see Step.SetRetry.
+// After a failure, or when 'ctx' is done, the context of the running functions is canceled,
+// the remaining steps are canceled, and the first error is returned once the running functions returned.
+// With KeepGoing, only the steps depending on a step that is not done are canceled.
+//
+// Steps without a function, already in progress, or terminated are ignored.
+// An error is returned without executing anything if a dependency is missing or cyclic.
+func (p *Progress) Run(ctx context.Context, opts ...RunOption) error {
+	config := runConfig{concurrency: 1}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	steps := p.runnableSteps()
+	sched, err := p.newSchedule(steps)
+	if err != nil {
+		return err
+	}
+	limit := config.concurrency
+	if limit < 1 {
+		limit = len(steps)
+	}
+	runningGroups := make(map[string]int)
+	available := func(step *Step) bool {
+		limit := config.groupLimits[sched.groups[step]]
+		return limit < 1 || runningGroups[sched.groups[step]] < limit
+	}
+
+	type result struct {
+		step *Step
+		err  error
+	}
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		pending = steps
+		running int
+		results = make(chan result)
+		errs    []error
+	)
+	for {
+		pending = p.cancelBlocked(pending, sched)
+		for ctx.Err() == nil && running < limit {
+			idx := sched.nextReady(pending, available)
+			if idx < 0 {
+				break
+			}
+			step := pending[idx]
+			pending = append(pending[:idx:idx], pending[idx+1:]...)
+			running++
+			runningGroups[sched.groups[step]]++
+			go func() { results <- result{step: step, err: step.run(ctx, config.middlewares)} }()
+		}
+		if running == 0 {
+			break
+		}
+
+		res := <-results
+		running--
+		runningGroups[sched.groups[res.step]]--
+		sched.finished[res.step] = completed(res.step.currentState())
+		if res.err != nil {
+			errs = append(errs, fmt.Errorf("step %q: %w", res.step.ID, res.err))
+			if !config.keepGoing {
+				cancel()
+			}
+		}
+	}
+	p.cancelSteps(pending)
+
+	switch {
+	case len(errs) == 0:
+		return parent.Err()
+	case config.keepGoing:
+		return errors.Join(errs...)
+	default:
+		return errs[0]
+	}
+}
+
+// cancelBlocked cancels the pending steps having a finished dependency that is not done,
+// and returns the other pending steps.
+func (p *Progress) cancelBlocked(pending []*Step, sched *schedule) []*Step {
+	for changed := true; changed; {
+		changed = false
+		remaining := pending[:0:0]
+		for _, step := range pending {
+			blocker := sched.blockingDependency(step)
+			if blocker == nil {
+				remaining = append(remaining, step)
+				continue
+			}
+			p.mainMutex.Lock()
+			if step.runnable() {
+				step.setError(fmt.Errorf("blocked by %q", blocker.ID))
+				step.cancel(p.now())
+				if p.isTerminal() {
+					p.terminate()
+				}
+			}
+			p.mainMutex.Unlock()
+			sched.finished[step] = false
+			changed = true
+		}
+		pending = remaining
+	}
+	return pending
+}
+
+// runnableSteps returns the steps having a function and waiting to be executed.
+func (p *Progress) runnableSteps() []*Step {
+	p.mainMutex.RLock()
+	defer p.mainMutex.RUnlock()
+	var ret []*Step
+	for _, step := range p.Steps {
+		if step.fn != nil && step.runnable() {
+			ret = append(ret, step)
+		}
+	}
+	return ret
+}
+
+// completed returns true if a step in 'state' satisfies the dependencies of the steps depending on it.
+func completed(state State) bool {
+	return state == StateDone || state == StateSkipped
+}
+
+// runnable returns true if the step can be started by the runner.
+func (s *Step) runnable() bool {
+	return s.State == StateNotStarted || s.State == StateInterrupted
+}
+
+// run starts the step, calls its function, and terminates the step depending on the returned error.
+// A step that is no longer runnable, i.e., started concurrently, is ignored.
+func (s *Step) run(ctx context.Context, middlewares []Middleware) error {
+	s.parent.mainMutex.Lock()
+	if !s.runnable() {
+		s.parent.mainMutex.Unlock()
+		return nil
+	}
+	fn, retry, skipIf, skipReason := s.fn, s.retry, s.skipIf, s.skipReason
+	s.parent.mainMutex.Unlock()
+
+	// the condition is evaluated without holding the lock, so it can inspect the Progress
+	skip := skipIf != nil && skipIf()
+	s.parent.mainMutex.Lock()
+	if !s.runnable() {
+		s.parent.mainMutex.Unlock()
+		return nil
+	}
+	if skip {
+		s.skip(skipReason, s.parent.now())
+		if s.parent.isTerminal() {
+			s.parent.terminate()
+		}
+		s.parent.mainMutex.Unlock()
+		return nil
+	}
+	s.start(s.parent.now())
+	s.parent.mainMutex.Unlock()
+	for idx := len(middlewares) - 1; idx >= 0; idx-- {
+		fn = middlewares[idx](fn)
+	}
+	ctx = NewStepContext(ctx, s)
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		startedAt := s.parent.now()
+		err = callStepFunc(ctx, fn, s)
+		if retry != nil {
+			s.recordAttempt(Attempt{StartedAt: startedAt, DoneAt: s.parent.now(), Error: errorMessage(err)})
+		}
+		if err == nil || ctx.Err() != nil || s.currentState().IsTerminal() || !retry.shouldRetry(attempt, err) {
+			break
+		}
+		timer := time.NewTimer(retry.delay(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	s.finish(ctx, err)
+	return err
+}
+
+// PanicError is returned by Progress.Run when a step function panics; the step is marked as failed.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value interface{}
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+// Error returns the panic value followed by the stack trace, like an unrecovered panic.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v\n\n%s", e.Value, e.Stack)
+}
+
+// callStepFunc calls 'fn', converting a panic into a *PanicError.
+func callStepFunc(ctx context.Context, fn StepFunc, step *Step) (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			err = &PanicError{Value: value, Stack: debug.Stack()}
+		}
+	}()
+	return fn(ctx, step)
+}
+
+// recordAttempt appends 'attempt' to the attempts of the step and publishes the change.
+func (s *Step) recordAttempt(attempt Attempt) {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	s.Attempts = append(s.Attempts, attempt)
+	s.parent.publishStep(s)
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// currentState returns the state of the step, safe for concurrent use.
+func (s *Step) currentState() State {
+	s.parent.mainMutex.RLock()
+	defer s.parent.mainMutex.RUnlock()
+	return s.State
+}
+
+// finish terminates the step after its function returned 'err', unless the function already terminated it.
+// The step is canceled if 'ctx' is done.
+func (s *Step) finish(ctx context.Context, err error) {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	if s.State.IsTerminal() {
+		return
+	}
+	now := s.parent.now()
+	switch {
+	case err == nil:
+		s.done(now)
+	case ctx.Err() != nil:
+		s.cancel(now)
+	default:
+		s.fail(err, now)
+	}
+	if s.parent.isTerminal() {
+		s.parent.terminate()
+	}
+}
+
+// cancelSteps cancels the provided steps if they are not started yet.
+func (p *Progress) cancelSteps(steps []*Step) {
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	now := p.now()
+	for _, step := range steps {
+		if step.runnable() {
+			step.cancel(now)
+		}
+	}
+	if p.isTerminal() {
+		p.terminate()
+	}
+}
@@ -0,0 +1,374 @@
+package progress_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestRun(t *testing.T) {
+	prog := progress.New()
+	var order []string
+	record := func(ctx context.Context, step *progress.Step) error {
+		require.Equal(t, progress.StateInProgress, step.State)
+		order = append(order, step.ID)
+		return nil
+	}
+	prog.AddStep("step1").SetFunc(record)
+	prog.AddStep("step2").SetFunc(func(ctx context.Context, step *progress.Step) error {
+		order = append(order, step.ID)
+		step.Done()
+		return nil
+	})
+	prog.AddStep("step3").SetFunc(record)
+
+	require.NoError(t, prog.Run(context.Background()))
+	require.Equal(t, []string{"step1", "step2", "step3"}, order)
+	snapshot := prog.Snapshot()
+	require.Equal(t, progress.StateDone, snapshot.State)
+	require.Equal(t, 3, snapshot.Completed)
+	require.NotNil(t, prog.Get("step1").DoneAt)
+}
+
+func TestRun_failure(t *testing.T) {
+	prog := progress.New()
+	errBoom := errors.New("boom")
+	prog.AddStep("step1").SetFunc(func(context.Context, *progress.Step) error { return nil })
+	prog.AddStep("step2").SetFunc(func(context.Context, *progress.Step) error { return errBoom })
+	prog.AddStep("step3").SetFunc(func(context.Context, *progress.Step) error {
+		t.Fatal("should not be called")
+		return nil
+	})
+
+	err := prog.Run(context.Background())
+	require.ErrorIs(t, err, errBoom)
+	require.EqualError(t, err, `step "step2": boom`)
+	require.Equal(t, progress.StateDone, prog.Get("step1").State)
+	require.Equal(t, progress.StateFailed, prog.Get("step2").State)
+	require.Equal(t, "boom", prog.Get("step2").Error)
+	require.Equal(t, progress.StateCanceled, prog.Get("step3").State)
+
+	snapshot := prog.Snapshot()
+	require.Equal(t, progress.StateFailed, snapshot.State)
+	require.Equal(t, 1, snapshot.Failed)
+	require.Equal(t, 1, snapshot.Canceled)
+	require.Equal(t, []string{"step2"}, prog.Summary().Failed)
+}
+
+func TestRun_contextCanceled(t *testing.T) {
+	prog := progress.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	prog.AddStep("step1").SetFunc(func(ctx context.Context, _ *progress.Step) error {
+		cancel()
+		return ctx.Err()
+	})
+	prog.AddStep("step2").SetFunc(func(context.Context, *progress.Step) error { return nil })
+	prog.AddStep("manual")
+
+	require.ErrorIs(t, prog.Run(ctx), context.Canceled)
+	require.Equal(t, progress.StateCanceled, prog.Get("step1").State)
+	require.Equal(t, progress.StateCanceled, prog.Get("step2").State)
+	require.Equal(t, progress.StateNotStarted, prog.Get("manual").State)
+}
+
+func TestRun_concurrency(t *testing.T) {
+	for _, tc := range []struct {
+		concurrency int
+		expected    int32
+	}{
+		{1, 1},
+		{2, 2},
+		{0, 6},
+	} {
+		prog := progress.New()
+		var running, max int32
+		for _, id := range []string{"a", "b", "c", "d", "e", "f"} {
+			prog.AddStep(id).SetFunc(func(context.Context, *progress.Step) error {
+				current := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					seen := atomic.LoadInt32(&max)
+					if current <= seen || atomic.CompareAndSwapInt32(&max, seen, current) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				return nil
+			})
+		}
+		require.NoError(t, prog.Run(context.Background(), progress.WithConcurrency(tc.concurrency)))
+		require.Equal(t, tc.expected, atomic.LoadInt32(&max), "concurrency=%d", tc.concurrency)
+		require.Equal(t, progress.StateDone, prog.Snapshot().State)
+	}
+}
+
+func TestRun_concurrencyFailure(t *testing.T) {
+	prog := progress.New()
+	started := make(chan struct{})
+	prog.AddStep("slow").SetFunc(func(ctx context.Context, _ *progress.Step) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	prog.AddStep("fail").SetFunc(func(context.Context, *progress.Step) error {
+		<-started
+		return errors.New("boom")
+	})
+	prog.AddStep("next").SetFunc(func(context.Context, *progress.Step) error { return nil })
+
+	require.EqualError(t, prog.Run(context.Background(), progress.WithConcurrency(2)), `step "fail": boom`)
+	require.Equal(t, progress.StateCanceled, prog.Get("slow").State)
+	require.Equal(t, progress.StateFailed, prog.Get("fail").State)
+	require.Equal(t, progress.StateCanceled, prog.Get("next").State)
+}
+
+func TestRun_dependencies(t *testing.T) {
+	prog := progress.New()
+	var (
+		mutex sync.Mutex
+		order []string
+	)
+	record := func(_ context.Context, step *progress.Step) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		order = append(order, step.ID)
+		return nil
+	}
+	prog.AddStep("deploy").SetFunc(record).DependsOn("test", "build")
+	prog.AddStep("test").SetFunc(record).DependsOn("build")
+	prog.AddStep("lint").SetFunc(record).DependsOn("fetch")
+	prog.AddStep("build").SetFunc(record).DependsOn("fetch")
+	prog.AddStep("fetch").Done()
+	require.Equal(t, []string{"test", "build"}, prog.Get("deploy").Dependencies())
+
+	require.NoError(t, prog.Run(context.Background(), progress.WithConcurrency(4)))
+	require.Equal(t, progress.StateDone, prog.Snapshot().State)
+	index := func(id string) int {
+		for idx, candidate := range order {
+			if candidate == id {
+				return idx
+			}
+		}
+		return -1
+	}
+	require.Len(t, order, 4)
+	require.Less(t, index("build"), index("test"))
+	require.Less(t, index("test"), index("deploy"))
+
+	// sequential execution follows the declaration order when possible
+	prog = progress.New()
+	order = nil
+	prog.AddStep("c").SetFunc(record).DependsOn("b")
+	prog.AddStep("a").SetFunc(record)
+	prog.AddStep("b").SetFunc(record)
+	require.NoError(t, prog.Run(context.Background()))
+	require.Equal(t, []string{"a", "b", "c"}, order)
+}
+
+func TestRun_blockedDependencies(t *testing.T) {
+	prog := progress.New()
+	ok := func(context.Context, *progress.Step) error { return nil }
+	prog.AddStep("build").SetFunc(func(context.Context, *progress.Step) error { return errors.New("boom") })
+	prog.AddStep("test").SetFunc(ok).DependsOn("build")
+	prog.AddStep("deploy").SetFunc(ok).DependsOn("test")
+	prog.AddStep("docs").SetFunc(ok)
+	prog.AddStep("external").Start()
+	prog.AddStep("notify").SetFunc(ok).DependsOn("external")
+
+	err := prog.Run(context.Background(), progress.KeepGoing())
+	require.EqualError(t, err, `step "build": boom`)
+	require.Equal(t, progress.StateFailed, prog.Get("build").State)
+	require.Equal(t, progress.StateCanceled, prog.Get("test").State)
+	require.Equal(t, `blocked by "build"`, prog.Get("test").Error)
+	require.Equal(t, progress.StateCanceled, prog.Get("deploy").State)
+	require.Equal(t, `blocked by "test"`, prog.Get("deploy").Error)
+	require.Equal(t, progress.StateDone, prog.Get("docs").State)
+	require.Equal(t, progress.StateCanceled, prog.Get("notify").State)
+	require.Equal(t, `blocked by "external"`, prog.Get("notify").Error)
+}
+
+func TestRun_invalidDependencies(t *testing.T) {
+	prog := progress.New()
+	ok := func(context.Context, *progress.Step) error { return nil }
+	prog.AddStep("a").SetFunc(ok).DependsOn("b")
+	prog.AddStep("b").SetFunc(ok).DependsOn("c")
+	prog.AddStep("c").SetFunc(ok).DependsOn("a")
+	require.ErrorIs(t, prog.Run(context.Background()), progress.ErrDependencyCycle)
+	require.Equal(t, progress.StateNotStarted, prog.Snapshot().State)
+
+	prog = progress.New()
+	prog.AddStep("a").SetFunc(ok).DependsOn("missing")
+	err := prog.Run(context.Background())
+	require.ErrorIs(t, err, progress.ErrStepNotFound)
+	require.EqualError(t, err, `step "a" depends on "missing": progress: no step matches the provided ID`)
+}
+
+func TestRun_middleware(t *testing.T) {
+	prog := progress.New()
+	var calls []string
+	trace := func(name string) progress.Middleware {
+		return func(next progress.StepFunc) progress.StepFunc {
+			return func(ctx context.Context, step *progress.Step) error {
+				calls = append(calls, name+">"+step.ID)
+				err := next(ctx, step)
+				calls = append(calls, name+"<"+step.ID)
+				return err
+			}
+		}
+	}
+	errBoom := errors.New("boom")
+	prog.AddStep("step1").SetFunc(func(context.Context, *progress.Step) error {
+		calls = append(calls, "step1")
+		return nil
+	})
+	prog.AddStep("step2").SetFunc(func(context.Context, *progress.Step) error { return errBoom })
+	swallow := func(next progress.StepFunc) progress.StepFunc {
+		return func(ctx context.Context, step *progress.Step) error {
+			_ = next(ctx, step)
+			return nil
+		}
+	}
+
+	require.NoError(t, prog.Run(context.Background(), progress.WithMiddleware(trace("a"), trace("b")), progress.WithMiddleware(swallow)))
+	require.Equal(t, []string{
+		"a>step1", "b>step1", "step1", "b<step1", "a<step1",
+		"a>step2", "b>step2", "b<step2", "a<step2",
+	}, calls)
+	require.Equal(t, progress.StateDone, prog.Get("step2").State)
+}
+
+func TestRun_panic(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").SetFunc(func(context.Context, *progress.Step) error { panic("boom") })
+	prog.AddStep("step2").SetFunc(func(context.Context, *progress.Step) error { return nil })
+
+	err := prog.Run(context.Background())
+	var panicErr *progress.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	require.Equal(t, "boom", panicErr.Value)
+	require.Contains(t, string(panicErr.Stack), "TestRun_panic")
+
+	step := prog.Get("step1")
+	require.Equal(t, progress.StateFailed, step.State)
+	require.True(t, strings.HasPrefix(step.Error, "panic: boom\n\ngoroutine "), step.Error)
+	require.Equal(t, progress.StateCanceled, prog.Get("step2").State)
+	require.Equal(t, progress.StateFailed, prog.Snapshot().State)
+}
+
+func TestRun_subProgress(t *testing.T) {
+	prog := progress.New()
+	download := func(ctx context.Context, chunks int) {
+		step := progress.StepFromContext(ctx)
+		for i := 0; i < chunks; i++ {
+			step.AddUnits(10)
+		}
+	}
+	prog.AddStep("download").SetTotalUnits(40).SetFunc(func(ctx context.Context, step *progress.Step) error {
+		step.SetDescription("downloading")
+		download(ctx, 4)
+		return nil
+	})
+
+	subscriber := prog.Subscribe()
+	require.NoError(t, prog.Run(context.Background()))
+	var rates []float64
+	for step := range subscriber {
+		if step.State == progress.StateInProgress {
+			rates = append(rates, step.Progress)
+		}
+	}
+	require.Equal(t, []float64{0, 0, 0.25, 0.5, 0.75, 1}, rates)
+	require.Equal(t, "downloading", prog.Get("download").Description)
+	require.Equal(t, progress.StateDone, prog.Get("download").State)
+}
+
+func TestRun_skipIf(t *testing.T) {
+	prog := progress.New()
+	called := map[string]bool{}
+	record := func(_ context.Context, step *progress.Step) error {
+		called[step.ID] = true
+		return nil
+	}
+	prog.AddStep("migrate").SetFunc(record).SkipIf("schema is up to date", func() bool { return true })
+	prog.AddStep("seed").SetFunc(record).SkipIf("never", func() bool { return false })
+	prog.AddStep("restart").SetFunc(record).DependsOn("migrate")
+
+	plan, err := prog.Plan()
+	require.NoError(t, err)
+	require.Equal(t, "schema is up to date", plan[0].Skip)
+	require.Empty(t, plan[1].Skip)
+
+	require.NoError(t, prog.Run(context.Background()))
+	require.Equal(t, map[string]bool{"seed": true, "restart": true}, called)
+	step := prog.Get("migrate")
+	require.Equal(t, progress.StateSkipped, step.State)
+	require.Equal(t, "schema is up to date", step.SkipReason)
+	require.Nil(t, step.StartedAt)
+
+	snapshot := prog.Snapshot()
+	require.Equal(t, progress.StateDone, snapshot.State)
+	require.Equal(t, 2, snapshot.Completed)
+	require.Equal(t, 1, snapshot.Skipped)
+	require.Equal(t, float64(1), snapshot.Progress)
+	require.Equal(t, []string{"migrate"}, prog.Summary().Skipped)
+}
+
+func TestRun_groupLimit(t *testing.T) {
+	prog := progress.New()
+	var (
+		mutex   sync.Mutex
+		running = map[string]int{}
+		max     = map[string]int{}
+	)
+	for i := 0; i < 6; i++ {
+		for _, group := range []string{"download", "compute"} {
+			prog.AddStep(fmt.Sprintf("%s%d", group, i)).SetGroup(group).SetFunc(func(_ context.Context, step *progress.Step) error {
+				mutex.Lock()
+				running[step.Group]++
+				if running[step.Group] > max[step.Group] {
+					max[step.Group] = running[step.Group]
+				}
+				mutex.Unlock()
+				time.Sleep(20 * time.Millisecond)
+				mutex.Lock()
+				running[step.Group]--
+				mutex.Unlock()
+				return nil
+			})
+		}
+	}
+
+	require.NoError(t, prog.Run(context.Background(), progress.WithConcurrency(8), progress.WithGroupLimit("download", 2)))
+	require.Equal(t, progress.StateDone, prog.Snapshot().State)
+	require.Equal(t, 2, max["download"])
+	require.Equal(t, 6, max["compute"])
+}
+
+func TestRun_priority(t *testing.T) {
+	prog := progress.New()
+	var order []string
+	record := func(_ context.Context, step *progress.Step) error {
+		order = append(order, step.ID)
+		return nil
+	}
+	prog.AddStep("cleanup").SetFunc(record).SetPriority(-1)
+	prog.AddStep("build").SetFunc(record)
+	prog.AddStep("notify").SetFunc(record).SetPriority(10).DependsOn("build")
+	prog.AddStep("test").SetFunc(record)
+	prog.AddStep("preview").SetFunc(record).SetPriority(5)
+
+	plan, err := prog.Plan()
+	require.NoError(t, err)
+	require.Equal(t, "preview", plan[0].ID)
+
+	require.NoError(t, prog.Run(context.Background()))
+	require.Equal(t, []string{"preview", "build", "notify", "test", "cleanup"}, order)
+}
@@ -0,0 +1,115 @@
+package progress
+
+import "fmt"
+
+// schedule holds the execution constraints of the steps run by Progress.Run, captured when the run starts.
+type schedule struct {
+	// deps are the resolved dependencies of each runnable step.
+	deps map[*Step][]*Step
+	// finished tracks the terminated dependencies, true if they are done or skipped.
+	finished   map[*Step]bool
+	groups     map[*Step]string
+	priorities map[*Step]int
+}
+
+// newSchedule resolves the dependencies of 'steps' and records the completion of the dependencies that
+// are not part of 'steps'. An error is returned if a dependency is missing or cyclic.
+func (p *Progress) newSchedule(steps []*Step) (*schedule, error) {
+	p.mainMutex.RLock()
+	defer p.mainMutex.RUnlock()
+	byID := make(map[string]*Step, len(p.Steps))
+	for _, step := range p.Steps {
+		byID[step.ID] = step
+	}
+	runnable := make(map[*Step]bool, len(steps))
+	for _, step := range steps {
+		runnable[step] = true
+	}
+
+	sched := &schedule{
+		deps:       make(map[*Step][]*Step, len(steps)),
+		finished:   make(map[*Step]bool),
+		groups:     make(map[*Step]string, len(steps)),
+		priorities: make(map[*Step]int, len(steps)),
+	}
+	for _, step := range steps {
+		sched.groups[step] = step.Group
+		sched.priorities[step] = step.priority
+		for _, id := range step.dependencies {
+			dep := byID[id]
+			if dep == nil {
+				return nil, fmt.Errorf("step %q depends on %q: %w", step.ID, id, ErrStepNotFound)
+			}
+			if !runnable[dep] {
+				sched.finished[dep] = completed(dep.State)
+			}
+			sched.deps[step] = append(sched.deps[step], dep)
+		}
+	}
+
+	// depth-first search of the runnable steps
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	marks := make(map[*Step]int, len(steps))
+	var visit func(step *Step) error
+	visit = func(step *Step) error {
+		switch marks[step] {
+		case visiting:
+			return fmt.Errorf("step %q: %w", step.ID, ErrDependencyCycle)
+		case visited:
+			return nil
+		}
+		marks[step] = visiting
+		for _, dep := range sched.deps[step] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		marks[step] = visited
+		return nil
+	}
+	for _, step := range steps {
+		if err := visit(step); err != nil {
+			return nil, err
+		}
+	}
+	return sched, nil
+}
+
+// nextReady returns the index of the pending step with the highest priority whose dependencies are done,
+// the first one in case of equality, or -1.
+// If 'available' is set, only the steps it accepts are considered.
+func (sched *schedule) nextReady(pending []*Step, available func(*Step) bool) int {
+	ret := -1
+	for idx, step := range pending {
+		if available != nil && !available(step) {
+			continue
+		}
+		if ret >= 0 && sched.priorities[step] <= sched.priorities[pending[ret]] {
+			continue
+		}
+		ready := true
+		for _, dep := range sched.deps[step] {
+			if !sched.finished[dep] {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			ret = idx
+		}
+	}
+	return ret
+}
+
+// blockingDependency returns the first finished dependency of 'step' that is not done, or nil.
+func (sched *schedule) blockingDependency(step *Step) *Step {
+	for _, dep := range sched.deps[step] {
+		if done, ok := sched.finished[dep]; ok && !done {
+			return dep
+		}
+	}
+	return nil
+}
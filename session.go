@@ -0,0 +1,141 @@
+package progress
+
+import "time"
+
+// Session records multiple executions of the same step plan, i.e., a nightly job, so runs can be compared
+// over time. The steps are matched by ID across runs.
+// A Session can be serialized to keep its history between executions; it is not safe for concurrent use.
+type Session struct {
+	Name string      `json:"name,omitempty" yaml:"name,omitempty"`
+	Runs []RunRecord `json:"runs,omitempty" yaml:"runs,omitempty"`
+}
+
+// RunRecord is the outcome of an execution recorded by Session.Record.
+type RunRecord struct {
+	State     State         `json:"state,omitempty" yaml:"state,omitempty"`
+	StartedAt *time.Time    `json:"started_at,omitempty" yaml:"started_at,omitempty"`
+	DoneAt    *time.Time    `json:"done_at,omitempty" yaml:"done_at,omitempty"`
+	Duration  time.Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
+	Steps     []StepRecord  `json:"steps,omitempty" yaml:"steps,omitempty"`
+}
+
+// StepRecord is the outcome of a step in a RunRecord.
+type StepRecord struct {
+	ID       string        `json:"id" yaml:"id"`
+	State    State         `json:"state,omitempty" yaml:"state,omitempty"`
+	Duration time.Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
+	Error    string        `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NewSession returns an empty Session named 'name'.
+func NewSession(name string) *Session {
+	return &Session{Name: name}
+}
+
+// Record appends the current outcome of 'prog' to the runs of the session and returns it.
+// It is usually called once the Progress is terminal.
+func (s *Session) Record(prog *Progress) RunRecord {
+	run := newRunRecord(prog)
+	s.Runs = append(s.Runs, run)
+	return run
+}
+
+// Stats returns the duration statistics of the steps over the runs of the session.
+func (s *Session) Stats() *DurationStats {
+	stats := NewDurationStats()
+	for _, run := range s.Runs {
+		stats.Add(run)
+	}
+	return stats
+}
+
+// newRunRecord returns the current outcome of 'prog'.
+func newRunRecord(prog *Progress) RunRecord {
+	snapshot := prog.Snapshot()
+	run := RunRecord{
+		State:     snapshot.State,
+		StartedAt: snapshot.StartedAt,
+		DoneAt:    snapshot.DoneAt,
+		Duration:  snapshot.TotalDuration,
+	}
+	for step := range prog.AllSteps() {
+		run.Steps = append(run.Steps, StepRecord{
+			ID:       step.ID,
+			State:    step.State,
+			Duration: step.Duration(),
+			Error:    step.Error,
+		})
+	}
+	return run
+}
+
+// Step returns the record of the step matching 'id', or nil if the step was not part of the run.
+func (r RunRecord) Step(id string) *StepRecord {
+	for idx := range r.Steps {
+		if r.Steps[idx].ID == id {
+			return &r.Steps[idx]
+		}
+	}
+	return nil
+}
+
+// Compare compares the runs at the indexes 'before' and 'after' of the session, see CompareRuns.
+// Negative indexes count from the last run, i.e., Compare(-2, -1) compares the two most recent runs.
+// It panics if an index is out of range.
+func (s *Session) Compare(before, after int) RunComparison {
+	index := func(idx int) int {
+		if idx < 0 {
+			return len(s.Runs) + idx
+		}
+		return idx
+	}
+	return CompareRuns(s.Runs[index(before)], s.Runs[index(after)])
+}
+
+// RunComparison describes the differences between two runs, see CompareRuns.
+type RunComparison struct {
+	// DurationDelta is the duration of the 'after' run minus the duration of the 'before' run.
+	DurationDelta time.Duration `json:"duration_delta,omitempty" yaml:"duration_delta,omitempty"`
+	// Steps contains a comparison for each step of the 'after' run, in order,
+	// followed by the steps only found in the 'before' run.
+	Steps []StepComparison `json:"steps,omitempty" yaml:"steps,omitempty"`
+}
+
+// StepComparison compares the records of a step in two runs.
+type StepComparison struct {
+	ID string `json:"id" yaml:"id"`
+	// Before and After are nil if the step was not part of the matching run.
+	Before *StepRecord `json:"before,omitempty" yaml:"before,omitempty"`
+	After  *StepRecord `json:"after,omitempty" yaml:"after,omitempty"`
+	// DurationDelta is the duration of the step in the 'after' run minus its duration in the 'before' run,
+	// or zero if the step is missing from a run.
+	DurationDelta time.Duration `json:"duration_delta,omitempty" yaml:"duration_delta,omitempty"`
+}
+
+// Regressed returns true if the step failed in the 'after' run but not in the 'before' run.
+func (c StepComparison) Regressed() bool {
+	return c.After != nil && c.After.State == StateFailed && (c.Before == nil || c.Before.State != StateFailed)
+}
+
+// Fixed returns true if the step failed in the 'before' run but not in the 'after' run.
+func (c StepComparison) Fixed() bool {
+	return c.Before != nil && c.Before.State == StateFailed && c.After != nil && c.After.State != StateFailed
+}
+
+// CompareRuns compares two runs of the same step plan, matching the steps by ID.
+func CompareRuns(before, after RunRecord) RunComparison {
+	ret := RunComparison{DurationDelta: after.Duration - before.Duration}
+	for idx := range after.Steps {
+		comparison := StepComparison{ID: after.Steps[idx].ID, After: &after.Steps[idx]}
+		if comparison.Before = before.Step(comparison.ID); comparison.Before != nil {
+			comparison.DurationDelta = comparison.After.Duration - comparison.Before.Duration
+		}
+		ret.Steps = append(ret.Steps, comparison)
+	}
+	for idx := range before.Steps {
+		if after.Step(before.Steps[idx].ID) == nil {
+			ret.Steps = append(ret.Steps, StepComparison{ID: before.Steps[idx].ID, Before: &before.Steps[idx]})
+		}
+	}
+	return ret
+}
@@ -0,0 +1,70 @@
+package progress_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progresstest"
+)
+
+// simulateRun executes a nightly plan, each step taking the provided duration and failing if negative.
+func simulateRun(durations map[string]time.Duration, ids ...string) *progress.Progress {
+	clock := progresstest.NewClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	prog := progress.New(progress.WithClock(clock))
+	for _, id := range ids {
+		step := prog.AddStep(id).Start()
+		if durations[id] < 0 {
+			clock.Advance(-durations[id])
+			step.Fail(errors.New("oops"))
+			continue
+		}
+		clock.Advance(durations[id])
+		step.Done()
+	}
+	return prog
+}
+
+func TestSession(t *testing.T) {
+	session := progress.NewSession("nightly")
+	first := session.Record(simulateRun(map[string]time.Duration{"fetch": time.Second, "build": 10 * time.Second, "lint": -time.Second}, "fetch", "build", "lint"))
+	require.Equal(t, progress.StateFailed, first.State)
+	require.Equal(t, 12*time.Second, first.Duration)
+	require.Equal(t, "oops", first.Step("lint").Error)
+	require.Nil(t, first.Step("test"))
+
+	session.Record(simulateRun(map[string]time.Duration{"fetch": time.Second, "build": 30 * time.Second, "test": -time.Second}, "fetch", "build", "test"))
+	require.Len(t, session.Runs, 2)
+
+	comparison := session.Compare(-2, -1)
+	require.Equal(t, 20*time.Second, comparison.DurationDelta)
+	require.Len(t, comparison.Steps, 4)
+	require.Equal(t, "build", comparison.Steps[1].ID)
+	require.Equal(t, 20*time.Second, comparison.Steps[1].DurationDelta)
+	require.Equal(t, "test", comparison.Steps[2].ID)
+	require.Nil(t, comparison.Steps[2].Before)
+	require.True(t, comparison.Steps[2].Regressed())
+	require.Equal(t, "lint", comparison.Steps[3].ID)
+	require.Nil(t, comparison.Steps[3].After)
+	require.False(t, comparison.Steps[3].Fixed())
+	require.Equal(t, comparison, progress.CompareRuns(session.Runs[0], session.Runs[1]))
+
+	// the history can be kept between executions
+	raw, err := json.Marshal(session)
+	require.NoError(t, err)
+	var loaded progress.Session
+	require.NoError(t, json.Unmarshal(raw, &loaded))
+	require.Equal(t, session.Name, loaded.Name)
+	require.Equal(t, comparison, loaded.Compare(0, 1))
+}
+
+func TestStepComparison_fixed(t *testing.T) {
+	before := progress.RunRecord{Steps: []progress.StepRecord{{ID: "a", State: progress.StateFailed}}}
+	after := progress.RunRecord{Steps: []progress.StepRecord{{ID: "a", State: progress.StateDone}}}
+	comparison := progress.CompareRuns(before, after)
+	require.True(t, comparison.Steps[0].Fixed())
+	require.False(t, comparison.Steps[0].Regressed())
+}
@@ -0,0 +1,46 @@
+package progress
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogTransitions logs a structured record on 'logger' for each step transition,
+// until the Progress becomes terminal or the returned stop function is called.
+//
+// Records are logged at the Info level, Warn for canceled steps, or Error for failed steps,
+// with the "step", "from", "state", "duration", and "error" attributes.
+func (p *Progress) LogTransitions(logger *slog.Logger) (stop func()) {
+	subscriber := p.SubscribeEvents()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for event := range subscriber {
+			step := event.Step
+			level := slog.LevelInfo
+			switch event.To {
+			case StateCanceled:
+				level = slog.LevelWarn
+			case StateFailed:
+				level = slog.LevelError
+			}
+			attrs := []slog.Attr{
+				slog.String("step", event.StepID),
+				slog.String("from", string(event.From)),
+				slog.String("state", string(event.To)),
+				slog.Duration("duration", step.Duration()),
+			}
+			if step.Description != "" {
+				attrs = append(attrs, slog.String("description", step.Description))
+			}
+			if step.Error != "" {
+				attrs = append(attrs, slog.String("error", step.Error))
+			}
+			logger.LogAttrs(context.Background(), level, "progress step transition", attrs...)
+		}
+	}()
+	return func() {
+		p.UnsubscribeEvents(subscriber)
+		<-done
+	}
+}
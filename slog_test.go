@@ -0,0 +1,43 @@
+package progress_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestLogTransitions(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	prog := progress.New()
+	prog.AddStep("step1").SetDescription("hello")
+	stop := prog.LogTransitions(logger)
+	prog.AddStep("step2")
+	prog.Get("step1").Start()
+	prog.Get("step1").Done()
+	prog.Get("step2").Cancel()
+	stop()
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	require.Len(t, records, 4)
+	require.Equal(t, "step2", records[0]["step"])
+	require.Equal(t, "not started", records[0]["state"])
+	require.Equal(t, "step1", records[1]["step"])
+	require.Equal(t, "in progress", records[1]["state"])
+	require.Equal(t, "hello", records[1]["description"])
+	require.Equal(t, "in progress", records[2]["from"])
+	require.Equal(t, "done", records[2]["state"])
+	require.Equal(t, "WARN", records[3]["level"])
+	require.Equal(t, "canceled", records[3]["state"])
+}
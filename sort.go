@@ -0,0 +1,56 @@
+package progress
+
+import (
+	"sort"
+	"time"
+)
+
+// stateOrder ranks the states for SortedByState, from the ones needing attention.
+var stateOrder = map[State]int{
+	StateFailed:      0,
+	StateCanceled:    1,
+	StateInterrupted: 2,
+	StateInProgress:  3,
+	StateNotStarted:  4,
+	StateSkipped:     5,
+	StateDone:        6,
+}
+
+// SortedByDuration returns a copy of the steps sorted by duration, from the slowest, see AllSteps.
+// Steps with the same duration, i.e., not started ones, keep their order.
+func (p *Progress) SortedByDuration() []*Step {
+	steps := p.copySteps()
+	// the durations of the steps in progress are computed once, so they do not change while sorting
+	durations := make(map[*Step]time.Duration, len(steps))
+	for _, step := range steps {
+		durations[step] = step.Duration()
+	}
+	sort.SliceStable(steps, func(i, j int) bool { return durations[steps[i]] > durations[steps[j]] })
+	return steps
+}
+
+// SortedByStartTime returns a copy of the steps sorted by start time, from the first started, see AllSteps.
+// Steps that were not started come last, in order.
+func (p *Progress) SortedByStartTime() []*Step {
+	steps := p.copySteps()
+	sort.SliceStable(steps, func(i, j int) bool {
+		a, b := steps[i].StartedAt, steps[j].StartedAt
+		switch {
+		case a == nil:
+			return false
+		case b == nil:
+			return true
+		default:
+			return a.Before(*b)
+		}
+	})
+	return steps
+}
+
+// SortedByState returns a copy of the steps grouped by state, see AllSteps: failed, canceled, interrupted,
+// in progress, not started, skipped, then done steps. Steps in the same state keep their order.
+func (p *Progress) SortedByState() []*Step {
+	steps := p.copySteps()
+	sort.SliceStable(steps, func(i, j int) bool { return stateOrder[steps[i].State] < stateOrder[steps[j].State] })
+	return steps
+}
@@ -0,0 +1,37 @@
+package progress_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progresstest"
+)
+
+func stepIDs(steps []*progress.Step) []string {
+	ret := make([]string, 0, len(steps))
+	for _, step := range steps {
+		ret = append(ret, step.ID)
+	}
+	return ret
+}
+
+func TestSorted(t *testing.T) {
+	clock := progresstest.NewClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	prog := progress.New(progress.WithClock(clock))
+	prog.AddSteps("a", "b", "c", "d", "e")
+	prog.MustGet("b").Start()
+	clock.Advance(time.Second)
+	prog.MustGet("a").Start()
+	clock.Advance(time.Second)
+	prog.MustGet("a").Done()
+	prog.MustGet("d").Start()
+	clock.Advance(3 * time.Second)
+	prog.MustGet("d").Fail(errors.New("oops"))
+
+	require.Equal(t, []string{"b", "d", "a", "c", "e"}, stepIDs(prog.SortedByDuration()))
+	require.Equal(t, []string{"b", "a", "d", "c", "e"}, stepIDs(prog.SortedByStartTime()))
+	require.Equal(t, []string{"d", "b", "c", "e", "a"}, stepIDs(prog.SortedByState()))
+}
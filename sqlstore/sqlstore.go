@@ -0,0 +1,401 @@
+// Package sqlstore provides a database/sql implementation of progress.Store.
+package sqlstore // import "moul.io/progress/sqlstore"
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"moul.io/progress"
+)
+
+// Dialect describes the SQL flavor used by the database.
+type Dialect struct {
+	// Placeholder returns the bind parameter for the n-th argument of a query, starting at 1.
+	Placeholder func(n int) string
+}
+
+var (
+	// SQLite uses '?' placeholders.
+	SQLite = Dialect{Placeholder: func(int) string { return "?" }}
+
+	// Postgres uses '$n' placeholders.
+	Postgres = Dialect{Placeholder: func(n int) string { return "$" + strconv.Itoa(n) }}
+)
+
+// migration is a change of the schema.
+type migration struct {
+	// column is the progress_step column added by the migration, if any.
+	// The migration is skipped if the column already exists, i.e., for a database migrated before the schema
+	// was versioned.
+	column string
+	query  string
+}
+
+// migrations are applied in order by Migrate, the version of the schema being the number of applied migrations.
+// A change of the schema must be appended as a new migration, the previous ones are never modified.
+var migrations = []migration{
+	{query: `CREATE TABLE IF NOT EXISTS progress (
+		id TEXT NOT NULL PRIMARY KEY,
+		created_at TEXT NOT NULL
+	)`},
+	{query: `CREATE TABLE IF NOT EXISTS progress_step (
+		progress_id TEXT NOT NULL,
+		position INTEGER NOT NULL,
+		id TEXT NOT NULL,
+		description TEXT NOT NULL,
+		state TEXT NOT NULL,
+		started_at TEXT,
+		done_at TEXT,
+		data TEXT,
+		progress DOUBLE PRECISION NOT NULL,
+		actor TEXT NOT NULL,
+		PRIMARY KEY (progress_id, id)
+	)`},
+	{query: `CREATE TABLE IF NOT EXISTS progress_event (
+		progress_id TEXT NOT NULL,
+		position INTEGER NOT NULL,
+		step_id TEXT NOT NULL,
+		from_state TEXT NOT NULL,
+		to_state TEXT NOT NULL,
+		at TEXT NOT NULL,
+		actor TEXT NOT NULL,
+		PRIMARY KEY (progress_id, position)
+	)`},
+	{column: "units", query: `ALTER TABLE progress_step ADD COLUMN units BIGINT NOT NULL DEFAULT 0`},
+	{column: "total_units", query: `ALTER TABLE progress_step ADD COLUMN total_units BIGINT NOT NULL DEFAULT 0`},
+	{column: "error", query: `ALTER TABLE progress_step ADD COLUMN error TEXT NOT NULL DEFAULT ''`},
+	{column: "attempts", query: `ALTER TABLE progress_step ADD COLUMN attempts TEXT`},
+	{column: "skip_reason", query: `ALTER TABLE progress_step ADD COLUMN skip_reason TEXT NOT NULL DEFAULT ''`},
+	{column: "step_group", query: `ALTER TABLE progress_step ADD COLUMN step_group TEXT NOT NULL DEFAULT ''`},
+	{column: "tags", query: `ALTER TABLE progress_step ADD COLUMN tags TEXT`},
+	{column: "metadata", query: `ALTER TABLE progress_step ADD COLUMN metadata TEXT`},
+	{column: "logs", query: `ALTER TABLE progress_step ADD COLUMN logs TEXT`},
+}
+
+// Store persists steps and transitions in SQL tables.
+type Store struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+var _ progress.Store = (*Store)(nil)
+
+// New returns a Store using 'db'.
+// Migrate should be called once to create or upgrade the tables.
+func New(db *sql.DB, dialect Dialect) *Store {
+	return &Store{db: db, dialect: dialect}
+}
+
+// Migrate creates the tables if they don't exist yet, or upgrades them to the current schema.
+// The version of the schema is stored in the progress_schema table; it should not be called concurrently.
+func (s *Store) Migrate(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS progress_schema (version INTEGER NOT NULL)`); err != nil {
+		return err
+	}
+	version, err := s.schemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	for ; version < len(migrations); version++ {
+		if err := s.migrate(ctx, migrations[version], version+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schemaVersion returns the number of migrations applied to the database.
+func (s *Store) schemaVersion(ctx context.Context) (int, error) {
+	var version int
+	err := s.db.QueryRowContext(ctx, `SELECT version FROM progress_schema`).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		_, err = s.db.ExecContext(ctx, `INSERT INTO progress_schema (version) VALUES (0)`)
+	}
+	return version, err
+}
+
+// migrate applies 'm' and sets the version of the schema to 'version' in a single transaction.
+func (s *Store) migrate(ctx context.Context, m migration, version int) error {
+	skip := false
+	if m.column != "" {
+		// the query fails if the column does not exist
+		rows, err := s.db.QueryContext(ctx, "SELECT "+m.column+" FROM progress_step WHERE 1 = 0")
+		if err == nil {
+			skip = true
+			rows.Close()
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	if !skip {
+		if _, err := tx.ExecContext(ctx, m.query); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, s.rebind("UPDATE progress_schema SET version = ?"), version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Save implements progress.Store.
+func (s *Store) Save(ctx context.Context, id string, prog *progress.Progress) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint:errcheck
+
+	for _, table := range []string{"progress_event", "progress_step"} {
+		if _, err := tx.ExecContext(ctx, s.rebind("DELETE FROM "+table+" WHERE progress_id = ?"), id); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, s.rebind("DELETE FROM progress WHERE id = ?"), id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, s.rebind("INSERT INTO progress (id, created_at) VALUES (?, ?)"), id, formatTime(&prog.CreatedAt)); err != nil {
+		return err
+	}
+
+	insertStep := s.rebind(`INSERT INTO progress_step
+		(progress_id, position, id, description, state, started_at, done_at, data, progress, actor, step_group, error, skip_reason, units, total_units, attempts, tags, metadata, logs)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	for position, step := range slices.Collect(prog.AllSteps()) {
+		// the data is encoded with the DataCodec of the Progress, if any
+		rawData, err := step.MarshalData()
+		if err != nil {
+			return err
+		}
+		data, err := nullJSON(rawData, rawData == nil)
+		if err != nil {
+			return err
+		}
+		attempts, err := nullJSON(step.Attempts, len(step.Attempts) == 0)
+		if err != nil {
+			return err
+		}
+		tags, err := nullJSON(step.Tags, len(step.Tags) == 0)
+		if err != nil {
+			return err
+		}
+		metadata, err := nullJSON(step.Metadata, len(step.Metadata) == 0)
+		if err != nil {
+			return err
+		}
+		logs, err := nullJSON(step.Logs, len(step.Logs) == 0)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, insertStep,
+			id, position, step.ID, step.Description, string(step.State),
+			nullTime(step.StartedAt), nullTime(step.DoneAt), data, step.Progress, step.Actor, step.Group,
+			step.Error, step.SkipReason, step.Units, step.TotalUnits, attempts, tags, metadata, logs,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	insertEvent := s.rebind(`INSERT INTO progress_event
+		(progress_id, position, step_id, from_state, to_state, at, actor)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	for position, event := range prog.Events() {
+		_, err := tx.ExecContext(ctx, insertEvent,
+			id, position, event.StepID, string(event.From), string(event.To), formatTime(&event.At), event.Actor,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Load implements progress.Store.
+func (s *Store) Load(ctx context.Context, id string) (*progress.Progress, error) {
+	// the JSON representation is used as a pivot to attach the steps and the events to the Progress
+	var decoded struct {
+		Steps     []*progress.Step `json:"steps,omitempty"`
+		CreatedAt time.Time        `json:"created_at,omitempty"`
+		Events    []progress.Event `json:"events,omitempty"`
+	}
+
+	var createdAt string
+	err := s.db.QueryRowContext(ctx, s.rebind("SELECT created_at FROM progress WHERE id = ?"), id).Scan(&createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, progress.ErrProgressNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if decoded.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return nil, err
+	}
+
+	if decoded.Steps, err = s.loadSteps(ctx, id); err != nil {
+		return nil, err
+	}
+	if decoded.Events, err = s.loadEvents(ctx, id); err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(decoded)
+	if err != nil {
+		return nil, err
+	}
+	var prog progress.Progress
+	if err := json.Unmarshal(raw, &prog); err != nil {
+		return nil, err
+	}
+	return &prog, nil
+}
+
+func (s *Store) loadSteps(ctx context.Context, id string) ([]*progress.Step, error) {
+	rows, err := s.db.QueryContext(ctx, s.rebind(`SELECT id, description, state, started_at, done_at, data, progress, actor, step_group, error, skip_reason, units, total_units, attempts, tags, metadata, logs
+		FROM progress_step WHERE progress_id = ? ORDER BY position`), id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var steps []*progress.Step
+	for rows.Next() {
+		var (
+			step              progress.Step
+			state             string
+			startedAt, doneAt sql.NullString
+			data, attempts    sql.NullString
+			tags, metadata    sql.NullString
+			logs              sql.NullString
+		)
+		if err := rows.Scan(&step.ID, &step.Description, &state, &startedAt, &doneAt, &data, &step.Progress, &step.Actor, &step.Group, &step.Error, &step.SkipReason, &step.Units, &step.TotalUnits, &attempts, &tags, &metadata, &logs); err != nil {
+			return nil, err
+		}
+		step.State = progress.State(state)
+		if step.StartedAt, err = parseNullTime(startedAt); err != nil {
+			return nil, err
+		}
+		if step.DoneAt, err = parseNullTime(doneAt); err != nil {
+			return nil, err
+		}
+		if data.Valid {
+			if err := json.Unmarshal([]byte(data.String), &step.Data); err != nil {
+				return nil, err
+			}
+		}
+		if attempts.Valid {
+			if err := json.Unmarshal([]byte(attempts.String), &step.Attempts); err != nil {
+				return nil, err
+			}
+		}
+		if tags.Valid {
+			if err := json.Unmarshal([]byte(tags.String), &step.Tags); err != nil {
+				return nil, err
+			}
+		}
+		if metadata.Valid {
+			if err := json.Unmarshal([]byte(metadata.String), &step.Metadata); err != nil {
+				return nil, err
+			}
+		}
+		if logs.Valid {
+			if err := json.Unmarshal([]byte(logs.String), &step.Logs); err != nil {
+				return nil, err
+			}
+		}
+		steps = append(steps, &step)
+	}
+	return steps, rows.Err()
+}
+
+func (s *Store) loadEvents(ctx context.Context, id string) ([]progress.Event, error) {
+	rows, err := s.db.QueryContext(ctx, s.rebind(`SELECT step_id, from_state, to_state, at, actor
+		FROM progress_event WHERE progress_id = ? ORDER BY position`), id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []progress.Event
+	for rows.Next() {
+		var (
+			event    progress.Event
+			from, to string
+			at       string
+		)
+		if err := rows.Scan(&event.StepID, &from, &to, &at, &event.Actor); err != nil {
+			return nil, err
+		}
+		event.From = progress.State(from)
+		event.To = progress.State(to)
+		if event.At, err = time.Parse(time.RFC3339Nano, at); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// rebind replaces the '?' placeholders of a query with the ones of the dialect.
+func (s *Store) rebind(query string) string {
+	var (
+		builder strings.Builder
+		n       int
+	)
+	for _, r := range query {
+		if r == '?' {
+			n++
+			builder.WriteString(s.dialect.Placeholder(n))
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+func formatTime(t *time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// nullJSON returns the JSON representation of 'value', or NULL if 'isNull' is true.
+func nullJSON(value interface{}, isNull bool) (sql.NullString, error) {
+	if isNull {
+		return sql.NullString{}, nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(raw), Valid: true}, nil
+}
+
+func nullTime(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: formatTime(t), Valid: true}
+}
+
+func parseNullTime(value sql.NullString) (*time.Time, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value.String)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
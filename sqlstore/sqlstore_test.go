@@ -0,0 +1,141 @@
+package sqlstore_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+	"moul.io/progress"
+	"moul.io/progress/sqlstore"
+)
+
+func TestStore(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "progress.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	store := sqlstore.New(db, sqlstore.SQLite)
+	require.NoError(t, store.Migrate(ctx))
+	require.NoError(t, store.Migrate(ctx)) // idempotent
+
+	_, err = store.Load(ctx, "migration")
+	require.Equal(t, progress.ErrProgressNotFound, err)
+
+	prog := progress.New()
+	prog.AddStep("step1").SetDescription("hello").SetData(map[string]interface{}{"foo": "bar"}).Done()
+	prog.AddStep("step2").SetActor("worker-1").SetProgress(0.3)
+	prog.AddStep("step3").SetTotalUnits(100).SetGroup("build").AddTag("compute", "linux").SetMeta("host", "builder-1")
+	prog.Get("step3").Logf("fetching")
+	require.NoError(t, store.Save(ctx, "migration", prog))
+
+	loaded, err := store.Load(ctx, "migration")
+	require.NoError(t, err)
+	require.True(t, prog.CreatedAt.Equal(loaded.CreatedAt))
+	require.Len(t, loaded.Steps, 3)
+	require.Equal(t, []string{"step1", "step2", "step3"}, []string{loaded.Steps[0].ID, loaded.Steps[1].ID, loaded.Steps[2].ID})
+	require.Equal(t, "hello", loaded.Get("step1").Description)
+	require.Equal(t, map[string]interface{}{"foo": "bar"}, loaded.Get("step1").Data)
+	require.True(t, prog.Get("step1").DoneAt.Equal(*loaded.Get("step1").DoneAt))
+	require.Equal(t, progress.StateInProgress, loaded.Get("step2").State)
+	require.Equal(t, 0.3, loaded.Get("step2").Progress)
+	require.Equal(t, "worker-1", loaded.Get("step2").Actor)
+	require.Nil(t, loaded.Get("step3").StartedAt)
+	require.Equal(t, int64(100), loaded.Get("step3").TotalUnits)
+	require.Equal(t, "build", loaded.Get("step3").Group)
+	require.Equal(t, []string{"compute", "linux"}, loaded.Get("step3").Tags)
+	require.Equal(t, "builder-1", loaded.Get("step3").Meta("host"))
+	require.Equal(t, "fetching", loaded.Get("step3").Logs[0].Message)
+	require.Equal(t, len(prog.Events()), len(loaded.Events()))
+	require.Equal(t, prog.Events()[3].To, loaded.Events()[3].To)
+
+	// saving again replaces the previous version
+	prog.Get("step2").Done()
+	prog.Get("step3").Done()
+	require.NoError(t, store.Save(ctx, "migration", prog))
+	loaded, err = store.Load(ctx, "migration")
+	require.NoError(t, err)
+	require.Len(t, loaded.Steps, 3)
+	require.Equal(t, progress.StateDone, loaded.Snapshot().State)
+	require.Equal(t, len(prog.Events()), len(loaded.Events()))
+
+	// several progresses can share the same database
+	other := progress.New()
+	other.AddStep("foo")
+	require.NoError(t, store.Save(ctx, "other", other))
+	loaded, err = store.Load(ctx, "other")
+	require.NoError(t, err)
+	require.Len(t, loaded.Steps, 1)
+}
+
+func TestStore_migrateLegacy(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "progress.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	ctx := context.Background()
+
+	// the first version of the schema, before the units, errors, attempts, groups, tags, metadata, and logs
+	for _, query := range []string{
+		`CREATE TABLE progress (id TEXT NOT NULL PRIMARY KEY, created_at TEXT NOT NULL)`,
+		`CREATE TABLE progress_step (progress_id TEXT NOT NULL, position INTEGER NOT NULL, id TEXT NOT NULL,
+			description TEXT NOT NULL, state TEXT NOT NULL, started_at TEXT, done_at TEXT, data TEXT,
+			progress DOUBLE PRECISION NOT NULL, actor TEXT NOT NULL, PRIMARY KEY (progress_id, id))`,
+		`CREATE TABLE progress_event (progress_id TEXT NOT NULL, position INTEGER NOT NULL, step_id TEXT NOT NULL,
+			from_state TEXT NOT NULL, to_state TEXT NOT NULL, at TEXT NOT NULL, actor TEXT NOT NULL,
+			PRIMARY KEY (progress_id, position))`,
+		`INSERT INTO progress (id, created_at) VALUES ('old', '2020-01-01T00:00:00Z')`,
+		`INSERT INTO progress_step (progress_id, position, id, description, state, started_at, done_at, progress, actor)
+			VALUES ('old', 0, 'step1', 'hello', 'done', '2020-01-01T00:00:00Z', '2020-01-01T00:01:00Z', 1, 'worker-1')`,
+	} {
+		_, err := db.ExecContext(ctx, query)
+		require.NoError(t, err)
+	}
+
+	store := sqlstore.New(db, sqlstore.SQLite)
+	require.NoError(t, store.Migrate(ctx))
+	require.NoError(t, store.Migrate(ctx)) // idempotent
+
+	loaded, err := store.Load(ctx, "old")
+	require.NoError(t, err)
+	require.Equal(t, "hello", loaded.Get("step1").Description)
+	require.Equal(t, progress.StateDone, loaded.Get("step1").State)
+	require.Empty(t, loaded.Get("step1").Group)
+
+	prog := progress.New()
+	prog.AddStep("step1").SetGroup("build").AddTag("linux").SetMeta("host", "builder-1").Logf("fetching")
+	require.NoError(t, store.Save(ctx, "new", prog))
+	loaded, err = store.Load(ctx, "new")
+	require.NoError(t, err)
+	require.Equal(t, "build", loaded.Get("step1").Group)
+	require.Equal(t, []string{"linux"}, loaded.Get("step1").Tags)
+	require.Equal(t, "builder-1", loaded.Get("step1").Meta("host"))
+	require.Equal(t, "fetching", loaded.Get("step1").Logs[0].Message)
+
+	var version int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT version FROM progress_schema`).Scan(&version))
+	require.Greater(t, version, 3)
+}
+
+func TestStore_dataCodec(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "progress.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	ctx := context.Background()
+	store := sqlstore.New(db, sqlstore.SQLite)
+	require.NoError(t, store.Migrate(ctx))
+	type user struct {
+		Name string `json:"name"`
+	}
+	registry := progress.NewTypeRegistry()
+	registry.Register("user", user{})
+	prog := progress.New(progress.WithDataCodec(registry))
+	prog.AddStep("step1").SetData(user{Name: "foo"}).Done()
+	require.NoError(t, store.Save(ctx, "job", prog))
+
+	loaded, err := progress.OpenStore(ctx, store, "job", progress.WithDataCodec(registry))
+	require.NoError(t, err)
+	require.Equal(t, user{Name: "foo"}, loaded.MustGet("step1").Data)
+}
@@ -0,0 +1,105 @@
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultSSEHeartbeat = 15 * time.Second
+	sseRetry            = 3 * time.Second
+)
+
+// SSEHandler returns an http.Handler streaming the Progress as Server-Sent Events.
+//
+// A "snapshot" event is sent when the client connects, then a "step" event followed by a "snapshot" event
+// each time a step changes. A final "done" event is sent when the Progress becomes terminal.
+// Snapshot events carry an id, so a reconnecting client sending Last-Event-ID skips the initial snapshot
+// if nothing changed in the meantime.
+//
+// A comment line is sent every 'heartbeat' to keep the connection alive; a zero value uses 15 seconds.
+func SSEHandler(prog *Progress, heartbeat time.Duration) http.Handler {
+	if heartbeat <= 0 {
+		heartbeat = defaultSSEHeartbeat
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+
+		// subscribe before sending the initial snapshot to avoid missing updates
+		subscriber := prog.Subscribe()
+		defer prog.Unsubscribe(subscriber)
+		// the subscriber of an already terminal Progress is never closed, the stream ends right away
+		terminal := prog.IsDone()
+
+		fmt.Fprintf(w, "retry: %d\n\n", sseRetry.Milliseconds())
+		if r.Header.Get("Last-Event-ID") != prog.revisionTag() {
+			if err := writeSSESnapshot(w, prog); err != nil {
+				return
+			}
+		}
+		if terminal {
+			_, _ = io.WriteString(w, "event: done\ndata: {}\n\n")
+			flusher.Flush()
+			return
+		}
+		flusher.Flush()
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ticker.C:
+				if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+					return
+				}
+			case step, ok := <-subscriber:
+				if !ok {
+					_ = writeSSESnapshot(w, prog)
+					_, _ = io.WriteString(w, "event: done\ndata: {}\n\n")
+					flusher.Flush()
+					return
+				}
+				if step != nil {
+					if err := writeSSEEvent(w, "step", "", step); err != nil {
+						return
+					}
+				}
+				if err := writeSSESnapshot(w, prog); err != nil {
+					return
+				}
+			}
+			flusher.Flush()
+		}
+	})
+}
+
+func writeSSESnapshot(w io.Writer, prog *Progress) error {
+	id := prog.revisionTag()
+	return writeSSEEvent(w, "snapshot", id, prog.Snapshot())
+}
+
+func writeSSEEvent(w io.Writer, event, id string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		_, err = fmt.Fprintf(w, "event: %s\nid: %s\ndata: %s\n\n", event, id, data)
+	} else {
+		_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	}
+	return err
+}
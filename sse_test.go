@@ -0,0 +1,98 @@
+package progress_test
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+// readSSEEvents returns a function returning the next event and data of 'body', or empty strings at the end.
+func readSSEEvents(body io.Reader) func() (string, string) {
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+	return func() (string, string) {
+		var event, data string
+		for line := range lines {
+			switch {
+			case line == "":
+				if event != "" {
+					return event, data
+				}
+			case strings.HasPrefix(line, "event: "):
+				event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+		return "", ""
+	}
+}
+
+func TestSSEHandler(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1")
+	server := httptest.NewServer(progress.SSEHandler(prog, time.Hour))
+	defer server.Close()
+
+	res, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	nextEvent := readSSEEvents(res.Body)
+	event, data := nextEvent()
+	require.Equal(t, "snapshot", event)
+	require.Contains(t, data, `"state":"not started"`)
+
+	prog.Get("step1").Start()
+	event, data = nextEvent()
+	require.Equal(t, "step", event)
+	require.Contains(t, data, `"id":"step1"`)
+	event, data = nextEvent()
+	require.Equal(t, "snapshot", event)
+	require.Contains(t, data, `"state":"in progress"`)
+
+	prog.Get("step1").Done()
+	event, _ = nextEvent()
+	require.Equal(t, "step", event)
+	event, _ = nextEvent()
+	require.Equal(t, "snapshot", event)
+	event, data = nextEvent()
+	require.Equal(t, "snapshot", event)
+	require.Contains(t, data, `"state":"done"`)
+	event, _ = nextEvent()
+	require.Equal(t, "done", event)
+}
+
+func TestSSEHandler_terminal(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Done()
+	server := httptest.NewServer(progress.SSEHandler(prog, time.Hour))
+	defer server.Close()
+
+	res, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	nextEvent := readSSEEvents(res.Body)
+	event, data := nextEvent()
+	require.Equal(t, "snapshot", event)
+	require.Contains(t, data, `"state":"done"`)
+	event, _ = nextEvent()
+	require.Equal(t, "done", event)
+	event, _ = nextEvent()
+	require.Empty(t, event)
+}
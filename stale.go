@@ -0,0 +1,40 @@
+package progress
+
+import "time"
+
+// WithStaleThreshold flags the steps in progress for longer than 'threshold' as stale, so dashboards can
+// highlight hung work, see Snapshot.Stale. Step.SetStaleThreshold overrides it for a step.
+// By default, steps are never stale.
+func WithStaleThreshold(threshold time.Duration) Option {
+	return func(p *Progress) { p.staleThreshold = threshold }
+}
+
+// SetStaleThreshold flags the step as stale once in progress for longer than 'threshold', overriding
+// WithStaleThreshold; a negative value disables it for the step.
+// It returns itself (*Step) for chaining.
+func (s *Step) SetStaleThreshold(threshold time.Duration) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	s.staleThreshold = threshold
+	return s
+}
+
+// IsStale returns true if the step is in progress for longer than its staleness threshold.
+func (s *Step) IsStale() bool {
+	s.parent.mainMutex.RLock()
+	defer s.parent.mainMutex.RUnlock()
+	return s.isStale()
+}
+
+// isStale returns true if the step is in progress for longer than its staleness threshold.
+// The caller is responsible for holding the main lock.
+func (s *Step) isStale() bool {
+	if s.parent == nil || s.State != StateInProgress || s.StartedAt == nil {
+		return false
+	}
+	threshold := s.staleThreshold
+	if threshold == 0 {
+		threshold = s.parent.staleThreshold
+	}
+	return threshold > 0 && s.parent.since(*s.StartedAt) > threshold
+}
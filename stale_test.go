@@ -0,0 +1,51 @@
+package progress_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progresstest"
+)
+
+func TestWithStaleThreshold(t *testing.T) {
+	clock := progresstest.NewClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	prog := progress.New(progress.WithClock(clock), progress.WithStaleThreshold(time.Minute))
+	fetch := prog.AddStep("fetch").Start()
+	build := prog.AddStep("build").SetStaleThreshold(10 * time.Minute).Start()
+	watch := prog.AddStep("watch").SetStaleThreshold(-1).Start()
+	clock.Advance(time.Minute)
+	require.False(t, prog.Snapshot().Stale)
+
+	clock.Advance(time.Second)
+	snapshot := prog.Snapshot()
+	require.True(t, snapshot.Stale)
+	require.Equal(t, []string{"fetch"}, snapshot.StaleSteps)
+	require.True(t, fetch.IsStale())
+	require.False(t, build.IsStale())
+	require.False(t, watch.IsStale())
+
+	out, err := json.Marshal(prog)
+	require.NoError(t, err)
+	var decoded struct {
+		Steps []struct {
+			Stale bool `json:"stale"`
+		} `json:"steps"`
+		Snapshot struct {
+			Stale bool `json:"stale"`
+		} `json:"snapshot"`
+	}
+	require.NoError(t, json.Unmarshal(out, &decoded))
+	require.True(t, decoded.Snapshot.Stale)
+	require.True(t, decoded.Steps[0].Stale)
+	require.False(t, decoded.Steps[1].Stale)
+
+	clock.Advance(time.Hour)
+	require.Equal(t, []string{"fetch", "build"}, prog.Snapshot().StaleSteps)
+	fetch.Done()
+	build.Done()
+	require.False(t, prog.Snapshot().Stale)
+	require.False(t, fetch.IsStale())
+}
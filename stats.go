@@ -0,0 +1,108 @@
+package progress
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DurationStats accumulates the durations of the steps over many runs, matching the steps by ID,
+// i.e., to estimate the remaining time or to detect a step running slower than usual.
+// Only the steps that are done are accounted for. It is safe for concurrent use.
+type DurationStats struct {
+	mutex     sync.Mutex
+	durations map[string][]time.Duration
+	order     []string
+}
+
+// StepStats describes the durations of a step over many runs, see DurationStats.
+type StepStats struct {
+	ID    string        `json:"id" yaml:"id"`
+	Count int           `json:"count" yaml:"count"`
+	Min   time.Duration `json:"min" yaml:"min"`
+	Max   time.Duration `json:"max" yaml:"max"`
+	Avg   time.Duration `json:"avg" yaml:"avg"`
+	P50   time.Duration `json:"p50" yaml:"p50"`
+	P95   time.Duration `json:"p95" yaml:"p95"`
+}
+
+// NewDurationStats returns an empty DurationStats.
+func NewDurationStats() *DurationStats {
+	return &DurationStats{durations: make(map[string][]time.Duration)}
+}
+
+// Add accounts for the done steps of 'run'.
+func (d *DurationStats) Add(run RunRecord) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for _, step := range run.Steps {
+		if step.State != StateDone {
+			continue
+		}
+		if _, found := d.durations[step.ID]; !found {
+			d.order = append(d.order, step.ID)
+		}
+		d.durations[step.ID] = append(d.durations[step.ID], step.Duration)
+	}
+}
+
+// AddProgress accounts for the done steps of 'prog', usually once it is terminal.
+func (d *DurationStats) AddProgress(prog *Progress) {
+	d.Add(newRunRecord(prog))
+}
+
+// Step returns the statistics of the step matching 'id', and false if it was never done.
+func (d *DurationStats) Step(id string) (StepStats, bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	durations, found := d.durations[id]
+	if !found {
+		return StepStats{}, false
+	}
+	return computeStepStats(id, durations), true
+}
+
+// All returns the statistics of every step, in the order they were first accounted for.
+func (d *DurationStats) All() []StepStats {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	ret := make([]StepStats, 0, len(d.order))
+	for _, id := range d.order {
+		ret = append(ret, computeStepStats(id, d.durations[id]))
+	}
+	return ret
+}
+
+// Ratio returns 'duration' divided by the median duration of the step, i.e., 3 for a step 3x slower
+// than usual. It returns 0 if the median is zero.
+func (s StepStats) Ratio(duration time.Duration) float64 {
+	if s.P50 == 0 {
+		return 0
+	}
+	return float64(duration) / float64(s.P50)
+}
+
+func computeStepStats(id string, durations []time.Duration) StepStats {
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var total time.Duration
+	for _, duration := range sorted {
+		total += duration
+	}
+	return StepStats{
+		ID:    id,
+		Count: len(sorted),
+		Min:   sorted[0],
+		Max:   sorted[len(sorted)-1],
+		Avg:   total / time.Duration(len(sorted)),
+		P50:   percentile(sorted, 0.50),
+		P95:   percentile(sorted, 0.95),
+	}
+}
+
+// percentile returns the nearest-rank percentile 'p' of the 'sorted' durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[max(rank, 0)]
+}
@@ -0,0 +1,46 @@
+package progress_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestDurationStats(t *testing.T) {
+	session := progress.NewSession("nightly")
+	for _, build := range []time.Duration{10, 12, 11, 30, 9} {
+		session.Record(simulateRun(map[string]time.Duration{"fetch": time.Second, "build": build * time.Second}, "fetch", "build"))
+	}
+	// failed steps are ignored
+	session.Record(simulateRun(map[string]time.Duration{"fetch": time.Second, "build": -time.Hour}, "fetch", "build"))
+
+	stats := session.Stats()
+	build, found := stats.Step("build")
+	require.True(t, found)
+	require.Equal(t, progress.StepStats{
+		ID:    "build",
+		Count: 5,
+		Min:   9 * time.Second,
+		Max:   30 * time.Second,
+		Avg:   14400 * time.Millisecond,
+		P50:   11 * time.Second,
+		P95:   30 * time.Second,
+	}, build)
+	require.Equal(t, 3.0, build.Ratio(33*time.Second))
+
+	all := stats.All()
+	require.Len(t, all, 2)
+	require.Equal(t, "fetch", all[0].ID)
+	require.Equal(t, 6, all[0].Count)
+
+	_, found = stats.Step("lint")
+	require.False(t, found)
+	require.Zero(t, progress.StepStats{}.Ratio(time.Second))
+
+	stats.AddProgress(simulateRun(map[string]time.Duration{"lint": time.Second}, "lint"))
+	lint, found := stats.Step("lint")
+	require.True(t, found)
+	require.Equal(t, time.Second, lint.P95)
+}
@@ -0,0 +1,19 @@
+package progress
+
+import (
+	"context"
+	"errors"
+)
+
+// Store persists and restores Progress instances identified by an arbitrary 'id'.
+// Implementations are available in the sub-packages of this library.
+type Store interface {
+	// Save persists the current state of 'prog', replacing the previous version stored with the same 'id'.
+	Save(ctx context.Context, id string, prog *Progress) error
+
+	// Load restores a Progress previously saved with 'id'.
+	// It returns ErrProgressNotFound if nothing was saved with this 'id'.
+	Load(ctx context.Context, id string) (*Progress, error)
+}
+
+var ErrProgressNotFound = errors.New("progress: no progress matches the provided ID")
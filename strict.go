@@ -0,0 +1,48 @@
+package progress
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrInvalidTransition is wrapped by the errors reported in strict mode, see WithStrictMode.
+var ErrInvalidTransition = errors.New("progress: invalid step transition")
+
+// WithStrictMode validates every step transition against the state machine: terminated steps cannot be
+// started or terminated again, and steps not belonging to the Progress, i.e., the copies returned by
+// AllSteps or sent to subscribers, cannot be updated.
+//
+// An invalid transition is ignored and reported to 'handler' with an error wrapping ErrInvalidTransition,
+// instead of overwriting the timestamps of the step. If 'handler' is nil, it panics with the error.
+// The handler is called while the Progress is locked and must not use it.
+func WithStrictMode(handler func(err error)) Option {
+	return func(p *Progress) {
+		p.strictMode = true
+		p.strictHandler = handler
+	}
+}
+
+// canTransition returns true if 'method' can be applied to the step, 'allowed' listing the states
+// accepted in strict mode. The invalid transitions are reported to the strict mode handler.
+// The caller is responsible for holding the main lock.
+func (s *Step) canTransition(method string, allowed ...State) bool {
+	p := s.parent
+	if !p.strictMode {
+		return true
+	}
+	var err error
+	switch {
+	case !slices.Contains(p.Steps, s):
+		err = fmt.Errorf("%w: cannot Step.%s() step %q, it does not belong to the Progress", ErrInvalidTransition, method, s.ID)
+	case !slices.Contains(allowed, s.State):
+		err = fmt.Errorf("%w: cannot Step.%s() step %q, it is %s", ErrInvalidTransition, method, s.ID, s.State)
+	default:
+		return true
+	}
+	if p.strictHandler == nil {
+		panic(err)
+	}
+	p.strictHandler(err)
+	return false
+}
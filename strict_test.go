@@ -0,0 +1,57 @@
+package progress_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestWithStrictMode(t *testing.T) {
+	var errs []error
+	prog := progress.New(progress.WithStrictMode(func(err error) { errs = append(errs, err) }))
+	step := prog.AddStep("step1").Start()
+	step.Start()
+	step.Cancel()
+	startedAt, doneAt := step.StartedAt, step.DoneAt
+
+	// the timestamps of a terminated step are not overwritten
+	step.Start()
+	step.Done()
+	step.Fail(errors.New("oops"))
+	step.SetProgress(0.5)
+	require.Equal(t, progress.StateCanceled, step.State)
+	require.Equal(t, startedAt, step.StartedAt)
+	require.Equal(t, doneAt, step.DoneAt)
+
+	// the copies do not belong to the Progress
+	for copied := range prog.AllSteps() {
+		copied.Done()
+	}
+
+	require.Len(t, errs, 6)
+	for _, err := range errs {
+		require.ErrorIs(t, err, progress.ErrInvalidTransition)
+	}
+	require.EqualError(t, errs[0], `progress: invalid step transition: cannot Step.Start() step "step1", it is in progress`)
+	require.EqualError(t, errs[1], `progress: invalid step transition: cannot Step.Start() step "step1", it is canceled`)
+	require.EqualError(t, errs[5], `progress: invalid step transition: cannot Step.Done() step "step1", it does not belong to the Progress`)
+
+	// valid transitions are not reported
+	errs = nil
+	prog.AddStep("step2").SetProgress(0.2)
+	prog.MustGet("step2").Done()
+	prog.AddStep("step3").Skip("not needed")
+	require.Empty(t, errs)
+}
+
+func TestWithStrictMode_panic(t *testing.T) {
+	prog := progress.New(progress.WithStrictMode(nil))
+	step := prog.AddStep("step1").Cancel()
+	require.Panics(t, func() { step.Start() })
+
+	// without strict mode, a canceled step can be started again
+	step = progress.New().AddStep("step1").Cancel()
+	require.NotPanics(t, func() { step.Start() })
+}
@@ -0,0 +1,63 @@
+package progress
+
+import (
+	"sort"
+	"time"
+)
+
+const summarySlowestSteps = 5
+
+// Summary describes the outcome of a Progress, usually printed at the end of a run.
+type Summary struct {
+	State     State         `json:"state,omitempty" yaml:"state,omitempty"`
+	Total     int           `json:"total,omitempty" yaml:"total,omitempty"`
+	Completed int           `json:"completed,omitempty" yaml:"completed,omitempty"`
+	Duration  time.Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
+	// Slowest contains the started steps taking the most time, from the slowest.
+	Slowest []*Step `json:"slowest,omitempty" yaml:"slowest,omitempty"`
+	// Failed, Canceled, Skipped, Interrupted, and NotStarted contain the IDs of the steps in the matching state.
+	Failed      []string `json:"failed,omitempty" yaml:"failed,omitempty"`
+	Canceled    []string `json:"canceled,omitempty" yaml:"canceled,omitempty"`
+	Skipped     []string `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+	Interrupted []string `json:"interrupted,omitempty" yaml:"interrupted,omitempty"`
+	NotStarted  []string `json:"not_started,omitempty" yaml:"not_started,omitempty"`
+}
+
+// Summary computes the Summary of the Progress.
+// The returned steps are copies and can be used safely while the Progress is updated.
+func (p *Progress) Summary() Summary {
+	snapshot := p.Snapshot()
+	ret := Summary{
+		State:     snapshot.State,
+		Total:     snapshot.Total,
+		Completed: snapshot.Completed,
+		Duration:  snapshot.TotalDuration,
+	}
+
+	p.mainMutex.RLock()
+	defer p.mainMutex.RUnlock()
+	for _, step := range p.Steps {
+		switch step.State {
+		case StateFailed:
+			ret.Failed = append(ret.Failed, step.ID)
+		case StateCanceled:
+			ret.Canceled = append(ret.Canceled, step.ID)
+		case StateSkipped:
+			ret.Skipped = append(ret.Skipped, step.ID)
+		case StateInterrupted:
+			ret.Interrupted = append(ret.Interrupted, step.ID)
+		case StateNotStarted:
+			ret.NotStarted = append(ret.NotStarted, step.ID)
+		}
+		if step.StartedAt != nil {
+			ret.Slowest = append(ret.Slowest, step.publishedCopy())
+		}
+	}
+	sort.SliceStable(ret.Slowest, func(i, j int) bool {
+		return ret.Slowest[i].Duration() > ret.Slowest[j].Duration()
+	})
+	if len(ret.Slowest) > summarySlowestSteps {
+		ret.Slowest = ret.Slowest[:summarySlowestSteps]
+	}
+	return ret
+}
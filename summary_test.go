@@ -0,0 +1,31 @@
+package progress_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestSummary(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Start()
+	prog.AddStep("step2").Start()
+	time.Sleep(20 * time.Millisecond)
+	prog.Get("step2").Done()
+	prog.Get("step1").Done()
+	prog.AddStep("step3").Cancel()
+	prog.AddStep("step4")
+
+	summary := prog.Summary()
+	require.Equal(t, progress.StateStopped, summary.State)
+	require.Equal(t, 4, summary.Total)
+	require.Equal(t, 2, summary.Completed)
+	require.Len(t, summary.Slowest, 2)
+	require.Equal(t, "step1", summary.Slowest[0].ID)
+	require.Equal(t, "step2", summary.Slowest[1].ID)
+	require.Equal(t, []string{"step3"}, summary.Canceled)
+	require.Equal(t, []string{"step4"}, summary.NotStarted)
+	require.Empty(t, summary.Interrupted)
+}
@@ -0,0 +1,82 @@
+package progress
+
+// AddTag attaches 'tags' to the step, i.e., to slice a heterogeneous Progress by category.
+// Tags already attached are ignored.
+// It returns itself (*Step) for chaining.
+func (s *Step) AddTag(tags ...string) *Step {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	for _, tag := range tags {
+		if !s.hasTag(tag) {
+			// copy on write, the published copies of the step share the previous slice
+			s.Tags = append(s.Tags[:len(s.Tags):len(s.Tags)], tag)
+		}
+	}
+	s.parent.publishStep(s)
+	return s
+}
+
+// HasTag returns true if 'tag' is attached to the step.
+func (s *Step) HasTag(tag string) bool {
+	s.parent.mainMutex.RLock()
+	defer s.parent.mainMutex.RUnlock()
+	return s.hasTag(tag)
+}
+
+func (s *Step) hasTag(tag string) bool {
+	for _, candidate := range s.Tags {
+		if candidate == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterTags returns a SubscribeFilter only matching steps having one of the provided tags.
+func FilterTags(tags ...string) SubscribeFilter {
+	return func(step *Step) bool {
+		for _, tag := range tags {
+			if step.hasTag(tag) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// StepsByTag returns a copy of the steps having 'tag', in order, see AllSteps.
+func (p *Progress) StepsByTag(tag string) []*Step {
+	return p.Filter(FilterTags(tag))
+}
+
+// SnapshotFor computes the stats of the steps having 'tag', as if they were the only steps of the Progress.
+func (p *Progress) SnapshotFor(tag string) Snapshot {
+	p.mainMutex.RLock()
+	defer p.mainMutex.RUnlock()
+	var steps []*Step
+	for _, step := range p.Steps {
+		if step.hasTag(tag) {
+			steps = append(steps, step)
+		}
+	}
+	return p.snapshot(steps, len(steps))
+}
+
+// snapshotsByTag computes the stats of the steps having each tag, or returns nil if no step has tags.
+// The caller is responsible for holding the main lock.
+func (p *Progress) snapshotsByTag() map[string]Snapshot {
+	steps := make(map[string][]*Step)
+	for _, step := range p.Steps {
+		for _, tag := range step.Tags {
+			steps[tag] = append(steps[tag], step)
+		}
+	}
+	if len(steps) == 0 {
+		return nil
+	}
+	ret := make(map[string]Snapshot, len(steps))
+	for tag, tagged := range steps {
+		ret[tag] = p.snapshot(tagged, len(tagged))
+	}
+	return ret
+}
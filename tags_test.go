@@ -0,0 +1,51 @@
+package progress_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestTags(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("fetch-a").AddTag("network", "download")
+	prog.AddStep("fetch-b").AddTag("network").AddTag("download", "network")
+	prog.AddStep("build").AddTag("compute")
+	prog.AddStep("push").AddTag("network", "upload")
+
+	require.Equal(t, []string{"network", "download"}, prog.MustGet("fetch-b").Tags)
+	require.True(t, prog.MustGet("push").HasTag("upload"))
+	require.False(t, prog.MustGet("push").HasTag("download"))
+	require.Equal(t, []string{"fetch-a", "fetch-b"}, stepIDs(prog.StepsByTag("download")))
+	require.Empty(t, prog.StepsByTag("unknown"))
+
+	prog.MustGet("fetch-a").Done()
+	prog.MustGet("fetch-b").Start()
+	snapshot := prog.SnapshotFor("download")
+	require.Equal(t, 2, snapshot.Total)
+	require.Equal(t, 1, snapshot.Completed)
+	require.Equal(t, progress.StateInProgress, snapshot.State)
+	require.Equal(t, 0.75, snapshot.Progress)
+	require.Equal(t, progress.StateNotStarted, prog.SnapshotFor("upload").State)
+	require.Equal(t, 0, prog.SnapshotFor("unknown").Total)
+
+	byTag := prog.Snapshot().ByTag
+	require.Len(t, byTag, 4)
+	require.Equal(t, 2, byTag["download"].Total)
+	require.Equal(t, 0.75, byTag["download"].Progress)
+	require.Equal(t, progress.StateNotStarted, byTag["upload"].State)
+	require.Nil(t, progress.New().Snapshot().ByTag)
+}
+
+func TestSubscribe_filterTags(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("a").AddTag("upload")
+	prog.AddStep("b")
+	sub := prog.Subscribe(progress.FilterTags("upload"))
+	prog.MustGet("b").Start()
+	prog.MustGet("a").Start()
+	step := <-sub
+	require.Equal(t, "a", step.ID)
+	prog.Unsubscribe(sub)
+}
@@ -0,0 +1,117 @@
+package progress
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MarshalText implements encoding.TextMarshaler with a compact one-line representation of the current snapshot,
+// i.e., `3/7 42% doing=step2 failed=0 canceled=0 eta=12s`.
+func (p *Progress) MarshalText() ([]byte, error) {
+	return p.AppendText(nil)
+}
+
+// AppendText implements encoding.TextAppender, see MarshalText.
+func (p *Progress) AppendText(b []byte) ([]byte, error) {
+	snapshot := p.Snapshot()
+	b = strconv.AppendInt(b, int64(snapshot.Completed), 10)
+	b = append(b, '/')
+	b = strconv.AppendInt(b, int64(snapshot.Total), 10)
+	if !snapshot.Indeterminate {
+		b = append(b, ' ')
+		b = snapshot.appendPercent(b)
+		b = append(b, '%')
+	}
+	if snapshot.Doing != "" {
+		b = append(b, " doing="...)
+		b = appendTextValue(b, snapshot.Doing)
+	}
+	b = append(b, " failed="...)
+	b = strconv.AppendInt(b, int64(snapshot.Failed), 10)
+	b = append(b, " canceled="...)
+	b = strconv.AppendInt(b, int64(snapshot.Canceled), 10)
+	if snapshot.CompletionEstimate > 0 {
+		b = append(b, " eta="...)
+		b = append(b, snapshot.CompletionEstimate.Round(time.Second).String()...)
+	}
+	return b, nil
+}
+
+// appendTextValue appends a value, quoted if it would break the key=value format.
+func appendTextValue(b []byte, value string) []byte {
+	if strings.ContainsAny(value, " \t\n\"=") {
+		return strconv.AppendQuote(b, value)
+	}
+	return append(b, value...)
+}
+
+// String returns a humanized one-line representation of the snapshot, suitable for logs and status lines,
+// i.e., `3/5 (60%) — doing "step 2" — elapsed 1m12s — ETA 45s`.
+func (s Snapshot) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%d/%d", s.Completed, s.Total)
+	if !s.Indeterminate {
+		fmt.Fprintf(&builder, " (%s%%)", s.appendPercent(nil))
+	}
+	switch {
+	case s.Doing != "":
+		fmt.Fprintf(&builder, " — doing %q", s.Doing)
+	case s.Outcome == OutcomePartial:
+		builder.WriteString(" — ")
+		builder.WriteString(string(s.Outcome))
+	case s.State != "" && s.State != StateNotStarted:
+		builder.WriteString(" — ")
+		builder.WriteString(string(s.State))
+	}
+	if s.TotalDuration > 0 {
+		builder.WriteString(" — elapsed ")
+		builder.WriteString(s.TotalDuration.Round(time.Second).String())
+	}
+	if s.CompletionEstimate > 0 {
+		builder.WriteString(" — ETA ")
+		builder.WriteString(s.CompletionEstimate.Round(time.Second).String())
+	}
+	return builder.String()
+}
+
+// String returns the state as is, i.e., "in progress".
+func (s State) String() string {
+	return string(s)
+}
+
+// String returns a humanized one-line representation of the current snapshot, see Snapshot.String.
+func (p *Progress) String() string {
+	return p.Snapshot().String()
+}
+
+// String returns a concise one-line representation of the step, i.e., `build: done in 1.2s`,
+// `test: in progress 45%`, or `lint: failed in 3s: exit status 1`.
+// Like the other accessors, it is not synchronized: use it on a copy of a step that may change concurrently.
+func (s *Step) String() string {
+	var builder strings.Builder
+	builder.WriteString(s.ID)
+	builder.WriteString(": ")
+	builder.WriteString(string(s.State))
+	switch {
+	case s.State == StateInProgress && s.Progress > 0:
+		var format PercentFormat
+		if s.parent != nil {
+			format = s.parent.percentFormat
+		}
+		builder.WriteString(" " + format.Format(s.Progress, false))
+	case s.State.IsTerminal() && s.StartedAt != nil && s.DoneAt != nil:
+		builder.WriteString(" in ")
+		builder.WriteString(s.DoneAt.Sub(*s.StartedAt).Round(time.Millisecond).String())
+	}
+	switch {
+	case s.Error != "":
+		builder.WriteString(": ")
+		builder.WriteString(s.Error)
+	case s.SkipReason != "":
+		builder.WriteString(": ")
+		builder.WriteString(s.SkipReason)
+	}
+	return builder.String()
+}
@@ -0,0 +1,82 @@
+package progress_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progresstest"
+)
+
+func TestMarshalText(t *testing.T) {
+	prog := progress.New()
+	text, err := prog.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "0/0 0% failed=0 canceled=0", string(text))
+
+	prog.AddStep("step1").Done()
+	prog.AddStep("step2").Start()
+	prog.AddStep("step3").SetDescription("step 3").Start()
+	prog.AddStep("step4").Cancel()
+	prog.AddStep("step5").Fail(errors.New("oops"))
+	text, err = prog.MarshalText()
+	require.NoError(t, err)
+	require.Regexp(t, `^1/5 40% doing="step2, step 3" failed=1 canceled=1 eta=\d+s$`, string(text))
+
+	prog.Get("step2").Done()
+	prog.Get("step3").Done()
+	text, err = prog.AppendText([]byte("status: "))
+	require.NoError(t, err)
+	require.Equal(t, "status: 3/5 60% failed=1 canceled=1", string(text))
+}
+
+func TestSnapshot_String(t *testing.T) {
+	clock := progresstest.NewClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	prog := progress.New(progress.WithClock(clock))
+	require.Equal(t, "0/0 (0%)", prog.Snapshot().String())
+
+	for _, id := range []string{"step1", "step2", "step3", "step4", "step5"} {
+		prog.AddStep(id)
+	}
+	prog.Get("step1").Start()
+	clock.Advance(24 * time.Second)
+	prog.Get("step1").Done()
+	prog.Get("step2").Start()
+	clock.Advance(24 * time.Second)
+	prog.Get("step2").Done()
+	prog.Get("step3").Start()
+	clock.Advance(24 * time.Second)
+	prog.Get("step3").Done()
+	prog.Get("step4").SetDescription("step 4").Start()
+	require.Equal(t, `3/5 (70%) — doing "step 4" — elapsed 1m12s — ETA 31s`, prog.Snapshot().String())
+
+	prog.Get("step4").Fail(nil)
+	prog.Get("step5").Skip("")
+	require.Equal(t, "3/5 (80%) — done with failures — elapsed 1m12s", prog.Snapshot().String())
+}
+
+func TestStringers(t *testing.T) {
+	clock := progresstest.NewClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	prog := progress.New(progress.WithClock(clock))
+	build := prog.AddStep("build").Start()
+	test := prog.AddStep("test").Start().SetProgress(0.45)
+	lint := prog.AddStep("lint").Start()
+	deploy := prog.AddStep("deploy")
+	clock.Advance(1200 * time.Millisecond)
+	build.Done()
+	clock.Advance(1800 * time.Millisecond)
+	lint.Fail(errors.New("exit status 1"))
+
+	require.Equal(t, "in progress", fmt.Sprint(progress.StateInProgress))
+	require.Equal(t, "build: done in 1.2s", fmt.Sprint(build))
+	require.Equal(t, "test: in progress 45%", fmt.Sprint(test))
+	require.Equal(t, "lint: failed in 3s: exit status 1", fmt.Sprint(lint))
+	require.Equal(t, "deploy: not started", fmt.Sprint(deploy))
+	deploy.Skip("no credentials")
+	require.Equal(t, "deploy: skipped: no credentials", fmt.Sprint(deploy))
+	test.Done()
+	require.Equal(t, `2/4 (75%) — done with failures — elapsed 3s`, fmt.Sprint(prog))
+}
@@ -0,0 +1,24 @@
+package progress
+
+// SetExpectedTotal declares the final number of steps, so the completion rate is computed against it
+// while the steps are still being added, instead of jumping backwards each time a step is appended.
+// The steps not added yet count as not started, and the Progress is not terminal until they are all added.
+// Values below the current number of steps are ignored.
+func (p *Progress) SetExpectedTotal(n int) {
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	p.expectedTotal = n
+}
+
+// ExpectedTotal returns the number of steps declared with SetExpectedTotal, or 0.
+func (p *Progress) ExpectedTotal() int {
+	p.mainMutex.RLock()
+	defer p.mainMutex.RUnlock()
+	return p.expectedTotal
+}
+
+// total returns the number of steps used to compute the completion rate.
+// The caller is responsible for holding the main lock.
+func (p *Progress) total() int {
+	return max(len(p.Steps), p.expectedTotal)
+}
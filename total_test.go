@@ -0,0 +1,45 @@
+package progress_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestSetExpectedTotal(t *testing.T) {
+	prog := progress.New()
+	prog.SetExpectedTotal(4)
+	require.Equal(t, 4, prog.ExpectedTotal())
+	snapshot := prog.Snapshot()
+	require.Equal(t, 4, snapshot.Total)
+	require.Equal(t, 4, snapshot.NotStarted)
+
+	prog.AddStep("a").Done()
+	require.Equal(t, 0.25, prog.Progress())
+	prog.AddStep("b").Done()
+	require.Equal(t, 0.5, prog.Progress())
+	snapshot = prog.Snapshot()
+	require.Equal(t, progress.StateStopped, snapshot.State)
+	require.Equal(t, 2, snapshot.NotStarted)
+	require.Contains(t, snapshot.String(), "2/4 (50%) — stopped")
+	select {
+	case <-prog.DoneCh():
+		t.Fatal("the progress should wait for the expected steps")
+	default:
+	}
+
+	prog.AddStep("c").Done()
+	prog.AddStep("d").Done()
+	require.Equal(t, 1.0, prog.Progress())
+	require.Equal(t, progress.StateDone, prog.Snapshot().State)
+	<-prog.DoneCh()
+
+	// more steps than expected
+	prog = progress.New()
+	prog.SetExpectedTotal(1)
+	prog.AddStep("a").Done()
+	prog.AddStep("b")
+	require.Equal(t, 2, prog.Snapshot().Total)
+	require.Equal(t, 0.5, prog.Progress())
+}
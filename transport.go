@@ -0,0 +1,134 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Transport is an http.RoundTripper tracking each request as a step of a Progress.
+//
+// A step is started when the request is sent and marked as done when the response body is
+// fully read or closed, or canceled if the request fails. The units of the step count the
+// transferred bytes of the request and response bodies.
+type Transport struct {
+	// Progress receives the request steps.
+	Progress *Progress
+	// Base is the underlying RoundTripper, http.DefaultTransport if nil.
+	Base http.RoundTripper
+	// StepID returns the step ID of a request, "METHOD URL" if nil.
+	// An existing not started step is reused, else a " #n" suffix is appended to the ID.
+	StepID func(req *http.Request) string
+}
+
+var _ http.RoundTripper = (*Transport)(nil)
+
+// NewTransport returns a Transport tracking the requests sent through 'base' in 'prog'.
+func NewTransport(prog *Progress, base http.RoundTripper) *Transport {
+	return &Transport{Progress: prog, Base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	id := req.Method + " " + req.URL.String()
+	if t.StepID != nil {
+		id = t.StepID(req)
+	}
+	step, err := t.Progress.claimStep(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ContentLength > 0 {
+		step.SetTotalUnits(req.ContentLength)
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		req = req.Clone(req.Context())
+		req.Body = &countingBody{ReadCloser: req.Body, step: step}
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		step.Cancel()
+		return nil, err
+	}
+
+	if resp.ContentLength >= 0 {
+		step.SetTotalUnits(step.units() + resp.ContentLength)
+	} else {
+		step.SetTotalUnits(0)
+	}
+	if resp.Body == nil || resp.Body == http.NoBody || resp.ContentLength == 0 {
+		step.Done()
+		return resp, nil
+	}
+	resp.Body = &countingBody{ReadCloser: resp.Body, step: step, done: true}
+	return resp, nil
+}
+
+// claimStep returns a started step for 'id', creating it if needed.
+func (p *Progress) claimStep(id string) (*Step, error) {
+	for n := 1; ; n++ {
+		candidate := id
+		if n > 1 {
+			candidate = fmt.Sprintf("%s #%d", id, n)
+		}
+		if _, err := p.SafeAddStep(candidate); err != nil && err != ErrStepIDShouldBeUnique {
+			return nil, err
+		}
+
+		p.mainMutex.Lock()
+		for _, step := range p.Steps {
+			if step.ID == candidate && step.State == StateNotStarted {
+				step.start(p.now())
+				p.mainMutex.Unlock()
+				return step, nil
+			}
+		}
+		p.mainMutex.Unlock()
+	}
+}
+
+func (s *Step) units() int64 {
+	s.parent.mainMutex.RLock()
+	defer s.parent.mainMutex.RUnlock()
+	return s.Units
+}
+
+// countingBody adds the read bytes to the units of a step.
+// If 'done' is set, the step is marked as done at the end of the body or when it is closed.
+type countingBody struct {
+	io.ReadCloser
+	step *Step
+	done bool
+	once sync.Once
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.step.AddUnits(int64(n))
+	}
+	if err == io.EOF {
+		b.finish()
+	}
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.finish()
+	return err
+}
+
+func (b *countingBody) finish() {
+	if !b.done {
+		return
+	}
+	b.once.Do(func() { b.step.Done() })
+}
@@ -0,0 +1,60 @@
+package progress_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(strings.Repeat("a", 100-len(body))))
+	}))
+	defer server.Close()
+
+	prog := progress.New()
+	prog.AddStep("upload")
+	client := &http.Client{Transport: &progress.Transport{
+		Progress: prog,
+		StepID: func(req *http.Request) string {
+			if req.Method == http.MethodPost {
+				return "upload"
+			}
+			return "download"
+		},
+	}}
+
+	// pre-declared step
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("hello"))
+	require.NoError(t, err)
+	step := prog.Get("upload")
+	require.Equal(t, progress.StateInProgress, step.State)
+	_, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, progress.StateDone, step.State)
+	require.Equal(t, int64(100), step.Units)
+	require.Equal(t, int64(100), step.TotalUnits)
+
+	// new steps
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	require.Equal(t, progress.StateDone, prog.Get("download").State)
+	require.Equal(t, progress.StateDone, prog.Get("download #2").State)
+	require.Equal(t, int64(0), prog.Get("download #2").Units)
+
+	// failure
+	client.Transport.(*progress.Transport).StepID = nil
+	_, err = client.Get("http://127.0.0.1:0/")
+	require.Error(t, err)
+	require.Equal(t, progress.StateCanceled, prog.Get("GET http://127.0.0.1:0/").State)
+}
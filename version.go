@@ -0,0 +1,44 @@
+package progress
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SchemaVersion is the version of the serialized representation of a Progress.
+// It is embedded in the JSON, YAML, and binary outputs.
+// Documents without version are considered as version 0, written before versioning was introduced.
+// Documents written with an older version are migrated when loaded, whatever the format.
+const SchemaVersion = 1
+
+var ErrUnsupportedSchemaVersion = errors.New("progress: unsupported schema version")
+
+// migrations upgrade a decoded Progress from the version used as key to the next one.
+// They are applied by every format (JSON, YAML, and binary), before the steps are validated and attached.
+// When the serialized representation changes, SchemaVersion is bumped and a migration is added here.
+var migrations = map[int]func(p *Progress) error{
+	// version 0 and 1 share the same representation.
+}
+
+func checkSchemaVersion(version int) error {
+	if version < 0 || version > SchemaVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedSchemaVersion, version)
+	}
+	return nil
+}
+
+// migrate upgrades a Progress decoded from a document of 'version' to the current SchemaVersion.
+// The caller is responsible for holding the main lock.
+func (p *Progress) migrate(version int) error {
+	if err := checkSchemaVersion(version); err != nil {
+		return err
+	}
+	for ; version < SchemaVersion; version++ {
+		if migration, found := migrations[version]; found {
+			if err := migration(p); err != nil {
+				return fmt.Errorf("migrate from schema version %d: %w", version, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package progress_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"moul.io/progress"
+)
+
+func TestSchemaVersion(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").Done()
+
+	// JSON
+	{
+		out, err := json.Marshal(prog)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(string(out), `{"version":1,`))
+
+		// legacy documents without version are still supported
+		var loaded progress.Progress
+		require.NoError(t, json.Unmarshal([]byte(`{"steps":[{"id":"step1","state":"done"}]}`), &loaded))
+		require.Equal(t, progress.StateDone, loaded.Get("step1").State)
+
+		err = json.Unmarshal([]byte(`{"version":42,"steps":[]}`), &loaded)
+		require.True(t, errors.Is(err, progress.ErrUnsupportedSchemaVersion))
+	}
+
+	// YAML
+	{
+		out, err := yaml.Marshal(prog)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(string(out), "version: 1\n"))
+
+		var loaded progress.Progress
+		err = yaml.Unmarshal([]byte("version: 42\nsteps: []"), &loaded)
+		require.True(t, errors.Is(err, progress.ErrUnsupportedSchemaVersion))
+	}
+
+	// binary
+	{
+		out, err := prog.MarshalBinary()
+		require.NoError(t, err)
+		var loaded progress.Progress
+		require.NoError(t, loaded.UnmarshalBinary(out))
+		require.Equal(t, progress.StateDone, loaded.Get("step1").State)
+	}
+}
+
+func TestSchemaVersion_migration(t *testing.T) {
+	reset := progress.SetMigration(0, func(prog *progress.Progress) error {
+		// i.e., a state renamed in version 1
+		for _, step := range prog.Steps {
+			if step.State == "finished" {
+				step.State = progress.StateDone
+			}
+		}
+		return nil
+	})
+	defer reset()
+
+	check := func(t *testing.T, loaded *progress.Progress) {
+		t.Helper()
+		require.Equal(t, progress.StateDone, loaded.MustGet("step1").State)
+		require.Equal(t, progress.StateNotStarted, loaded.MustGet("step2").State)
+		require.Equal(t, 1, loaded.Snapshot().Completed)
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var loaded progress.Progress
+		require.NoError(t, json.Unmarshal([]byte(`{"steps":[{"id":"step1","state":"finished"},{"id":"step2","state":"not started"}]}`), &loaded))
+		check(t, &loaded)
+
+		// current documents are not migrated
+		err := json.Unmarshal([]byte(`{"version":1,"steps":[{"id":"step1","state":"finished"}]}`), &loaded)
+		require.NoError(t, err)
+		require.Equal(t, progress.State("finished"), loaded.MustGet("step1").State)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		var loaded progress.Progress
+		require.NoError(t, yaml.Unmarshal([]byte("steps:\n- id: step1\n  state: finished\n- id: step2\n  state: not started\n"), &loaded))
+		check(t, &loaded)
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		// a version 0 document, encoded before the version field was introduced
+		var buf bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buf).Encode(struct {
+			Steps []*progress.Step
+		}{
+			Steps: []*progress.Step{{ID: "step1", State: "finished"}, {ID: "step2", State: progress.StateNotStarted}},
+		}))
+		var loaded progress.Progress
+		require.NoError(t, loaded.UnmarshalBinary(buf.Bytes()))
+		check(t, &loaded)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		reset := progress.SetMigration(0, func(*progress.Progress) error { return errors.New("oops") })
+		defer reset()
+		var loaded progress.Progress
+		err := json.Unmarshal([]byte(`{"steps":[]}`), &loaded)
+		require.EqualError(t, err, "migrate from schema version 0: oops")
+	})
+}
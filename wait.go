@@ -0,0 +1,43 @@
+package progress
+
+import "context"
+
+// Wait blocks until every step of the Progress reaches a terminal state or until 'ctx' is done.
+// It returns the context error if the context is done first.
+func (p *Progress) Wait(ctx context.Context) error {
+	select {
+	case <-p.DoneCh():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WaitFor blocks until the step matching 'id' reaches a terminal state or until 'ctx' is done.
+// It returns ErrStepNotFound if no step matches 'id', or the context error if the context is done first.
+func (p *Progress) WaitFor(ctx context.Context, id string) error {
+	step := p.Get(id)
+	if step == nil {
+		return ErrStepNotFound
+	}
+	select {
+	case <-step.waitCh():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// waitCh returns a chan that is closed when the step reaches a terminal state.
+func (s *Step) waitCh() <-chan struct{} {
+	s.parent.mainMutex.Lock()
+	defer s.parent.mainMutex.Unlock()
+	if s.doneCh == nil {
+		s.doneCh = make(chan struct{})
+		if s.State.IsTerminal() {
+			close(s.doneCh)
+			s.doneChClosed = true
+		}
+	}
+	return s.doneCh
+}
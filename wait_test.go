@@ -0,0 +1,61 @@
+package progress_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+)
+
+func TestWait(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1")
+	prog.AddStep("step2")
+
+	// timeout
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.Equal(t, context.DeadlineExceeded, prog.Wait(ctx))
+	}
+
+	go func() {
+		prog.Get("step1").Done()
+		prog.Get("step2").Done()
+	}()
+	require.NoError(t, prog.Wait(context.Background()))
+}
+
+func TestWaitFor(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1")
+	prog.AddStep("step2")
+	ctx := context.Background()
+
+	require.Equal(t, progress.ErrStepNotFound, prog.WaitFor(ctx, "unknown"))
+
+	// timeout
+	{
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		require.Equal(t, context.DeadlineExceeded, prog.WaitFor(ctx, "step2"))
+	}
+
+	go func() {
+		prog.Get("step2").Start()
+		time.Sleep(10 * time.Millisecond)
+		prog.Get("step2").Done()
+	}()
+	require.NoError(t, prog.WaitFor(ctx, "step2"))
+	require.Equal(t, progress.StateDone, prog.Get("step2").State)
+	require.Equal(t, progress.StateNotStarted, prog.Get("step1").State)
+
+	// already done
+	require.NoError(t, prog.WaitFor(ctx, "step2"))
+
+	// canceled steps are terminal too
+	prog.Get("step1").Cancel()
+	require.NoError(t, prog.WaitFor(ctx, "step1"))
+}
@@ -0,0 +1,70 @@
+package progress
+
+import (
+	"sync"
+	"time"
+)
+
+// Stall describes a Progress without step transition for longer than the timeout of a watchdog,
+// see Progress.Watchdog.
+type Stall struct {
+	// LastTransition is the time of the last step transition.
+	LastTransition time.Time
+	// Idle is the time elapsed since the last step transition.
+	Idle time.Duration
+	// Snapshot is the snapshot of the stalled Progress.
+	Snapshot Snapshot
+}
+
+// watchdogChecks is the number of times per timeout a watchdog checks the last step transition.
+const watchdogChecks = 4
+
+// Watchdog calls 'fn' when no step transition occurred for 'timeout' while the Progress is started and not
+// terminal yet, so orchestrators can alert or abort stalled runs.
+// The idle time is measured with the clock of the Progress, and checked every quarter of 'timeout' using its
+// tickers, see TickerClock: a stall is reported at most 1.25 times 'timeout' after the last transition.
+// 'fn' is called once per stall, from a dedicated goroutine, until the returned stop function is called.
+func (p *Progress) Watchdog(timeout time.Duration, fn func(Stall)) (stop func()) {
+	ticks, stopTicker := p.newTicker(max(timeout/watchdogChecks, time.Nanosecond))
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		defer stopTicker()
+		var notified time.Time
+		for {
+			select {
+			case <-ticks:
+			case <-done:
+				return
+			}
+
+			p.mainMutex.RLock()
+			last := p.lastTransition
+			idle := p.since(last)
+			stalled := p.isStarted() && !p.isTerminal() && !last.IsZero() && !last.Equal(notified) && idle >= timeout
+			p.mainMutex.RUnlock()
+
+			if stalled {
+				notified = last
+				fn(Stall{LastTransition: last, Idle: idle, Snapshot: p.Snapshot()})
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+		<-stopped
+	}
+}
+
+// isStarted returns true if at least one step left the not started state.
+// The caller is responsible for holding the main lock.
+func (p *Progress) isStarted() bool {
+	for _, step := range p.Steps {
+		if step.State != StateNotStarted {
+			return true
+		}
+	}
+	return false
+}
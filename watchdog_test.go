@@ -0,0 +1,54 @@
+package progress_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/progress"
+	"moul.io/progress/progresstest"
+)
+
+func TestWatchdog(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := progresstest.NewClock(start)
+	prog := progress.New(progress.WithClock(clock))
+	prog.AddStep("step1")
+	prog.AddStep("step2")
+	stalls := make(chan progress.Stall, 10)
+	stop := prog.Watchdog(20*time.Second, func(stall progress.Stall) { stalls <- stall })
+	defer stop()
+
+	// not started yet; the watchdog checks every 5s and each tick is received once the previous one is processed
+	clock.Advance(time.Minute)
+	clock.Advance(5 * time.Second)
+	require.Empty(t, stalls)
+
+	prog.Get("step1").Start()
+	clock.Advance(15 * time.Second)
+	require.Empty(t, stalls)
+	clock.Advance(5 * time.Second)
+	stall := <-stalls
+	require.Equal(t, 20*time.Second, stall.Idle)
+	require.Equal(t, `step1`, stall.Snapshot.Doing)
+	require.Equal(t, *prog.Get("step1").StartedAt, stall.LastTransition)
+
+	// once per stall
+	clock.Advance(time.Minute)
+	clock.Advance(5 * time.Second)
+	require.Empty(t, stalls)
+
+	prog.Get("step1").Done()
+	prog.Get("step2").Start()
+	clock.Advance(20 * time.Second)
+	stall = <-stalls
+	require.Equal(t, 20*time.Second, stall.Idle)
+	require.Equal(t, `step2`, stall.Snapshot.Doing)
+
+	prog.Get("step2").Done()
+	clock.Advance(time.Minute)
+	clock.Advance(5 * time.Second)
+	require.Empty(t, stalls)
+	stop()
+	stop()
+}
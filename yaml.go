@@ -0,0 +1,90 @@
+package progress
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// MarshalYAML is a custom YAML marshaler that automatically computes and append the current snapshot.
+func (p *Progress) MarshalYAML() (interface{}, error) {
+	p.mainMutex.RLock()
+	steps := p.Steps
+	p.mainMutex.RUnlock()
+	return struct {
+		Version   int       `yaml:"version"`
+		Steps     []*Step   `yaml:"steps,omitempty"`
+		CreatedAt time.Time `yaml:"created_at,omitempty"`
+		Snapshot  Snapshot  `yaml:"snapshot"`
+		Events    []Event   `yaml:"events,omitempty"`
+	}{
+		Version:   SchemaVersion,
+		Steps:     steps,
+		CreatedAt: p.CreatedAt,
+		Snapshot:  p.Snapshot(),
+		Events:    p.Events(),
+	}, nil
+}
+
+// UnmarshalYAML is a custom YAML unmarshaler that restores the steps and the event log.
+// The snapshot is ignored, it is computed again on demand.
+func (p *Progress) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var decoded struct {
+		Version   int       `yaml:"version,omitempty"`
+		Steps     []*Step   `yaml:"steps,omitempty"`
+		CreatedAt time.Time `yaml:"created_at,omitempty"`
+		Events    []Event   `yaml:"events,omitempty"`
+	}
+	if err := unmarshal(&decoded); err != nil {
+		return err
+	}
+	p.mainMutex.Lock()
+	defer p.mainMutex.Unlock()
+	p.Steps = decoded.Steps
+	p.CreatedAt = decoded.CreatedAt
+	return p.restore(decoded.Version, decoded.Events)
+}
+
+// MarshalYAML is a custom YAML marshaler that automatically computes and append some runtime metadata.
+// The data is encoded with the DataCodec of the Progress, if any.
+func (s *Step) MarshalYAML() (interface{}, error) {
+	type alias Step
+	enriched := struct {
+		alias    `yaml:",inline"`
+		Duration time.Duration `yaml:"duration,omitempty"`
+	}{
+		alias:    (alias)(*s),
+		Duration: s.Duration(),
+	}
+	data, err := s.marshalData()
+	if err != nil {
+		return nil, err
+	}
+	if raw, ok := data.(json.RawMessage); ok {
+		// the encoded data is converted to a generic value, so it is readable in the YAML output
+		enriched.Data = nil
+		if err := json.Unmarshal(raw, &enriched.Data); err != nil {
+			return nil, err
+		}
+	}
+	return enriched, nil
+}
+
+// UnmarshalYAML is a custom YAML unmarshaler that ignores the runtime metadata added by MarshalYAML.
+// The decoded step is only usable with the helpers once attached to a Progress, i.e., by Progress.UnmarshalYAML,
+// which decodes the data with its DataCodec.
+func (s *Step) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type alias Step
+	if err := unmarshal((*alias)(s)); err != nil {
+		return err
+	}
+	s.rawData = nil
+	if s.Data == nil {
+		return nil
+	}
+	raw, err := json.Marshal(s.Data)
+	if err != nil {
+		return err
+	}
+	s.rawData = raw
+	return nil
+}
@@ -0,0 +1,57 @@
+package progress_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"moul.io/progress"
+)
+
+func TestYAMLRoundTrip(t *testing.T) {
+	prog := progress.New()
+	prog.AddStep("step1").SetDescription("hello").Done()
+	prog.AddStep("step2").SetData("world").Start()
+	prog.AddStep("step3")
+
+	out, err := yaml.Marshal(prog)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "id: step1")
+	require.Contains(t, string(out), "description: hello")
+	require.Contains(t, string(out), "state: in progress")
+	require.Contains(t, string(out), "snapshot:")
+
+	var loaded progress.Progress
+	require.NoError(t, yaml.Unmarshal(out, &loaded))
+	require.True(t, prog.CreatedAt.Equal(loaded.CreatedAt))
+	require.Len(t, loaded.Steps, 3)
+	require.Equal(t, "hello", loaded.Get("step1").Description)
+	require.Equal(t, progress.StateDone, loaded.Get("step1").State)
+	require.Equal(t, "world", loaded.Get("step2").Data)
+	require.Equal(t, len(prog.Events()), len(loaded.Events()))
+
+	loaded.Get("step2").Done()
+	loaded.Get("step3").Done()
+	require.Equal(t, progress.StateDone, loaded.Snapshot().State)
+}
+
+func TestYAMLUnmarshal_plan(t *testing.T) {
+	// a hand-written step plan
+	plan := `
+steps:
+  - id: fetch
+    description: fetch sources
+  - id: build
+  - id: deploy
+    data: {env: prod}
+`
+	var prog progress.Progress
+	require.NoError(t, yaml.Unmarshal([]byte(plan), &prog))
+	require.Len(t, prog.Steps, 3)
+	require.Equal(t, "fetch sources", prog.Get("fetch").Description)
+	require.Equal(t, map[string]interface{}{"env": "prod"}, prog.Get("deploy").Data)
+	require.Equal(t, progress.StateNotStarted, prog.Get("build").State)
+	require.Equal(t, 3, prog.Snapshot().NotStarted)
+
+	require.Equal(t, progress.ErrStepIDShouldBeUnique, yaml.Unmarshal([]byte("steps: [{id: a}, {id: a}]"), &prog))
+}